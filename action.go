@@ -1,6 +1,13 @@
 package main
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
 	"github.com/ma-vin/packages-action/config"
 	"github.com/ma-vin/packages-action/service"
 	"github.com/ma-vin/typewriter/logger"
@@ -12,17 +19,34 @@ var (
 	branchName string
 )
 
-// Main funtion to execute the actions process
+// Main funtion to execute the actions process. Either as GitHub action with environment variables or as command line interface
 func main() {
+	stop, err := applyCommand(os.Args[1:], os.Stderr)
+	checkError(err)
+	if stop {
+		return
+	}
+
 	printVersion()
 	logger.Information("Start packages action")
 	initAll()
 
-	var loadedConfig, err = config.ReadConfiguration()
+	loadedConfig, err := config.ReadConfiguration()
 
 	checkError(err)
 
-	err = service.DeleteVersions(loadedConfig)
+	ctx, cancel := createRunContext(loadedConfig)
+	switch loadedConfig.Mode {
+	case config.MODE_RESTORE:
+		err = service.RestoreVersions(ctx, loadedConfig)
+	case config.MODE_LIST:
+		err = service.ListPackages(ctx, loadedConfig)
+	case config.MODE_APPLY:
+		err = service.ApplyPlan(ctx, loadedConfig)
+	default:
+		err = service.DeleteVersions(ctx, loadedConfig)
+	}
+	cancel()
 	checkError(err)
 
 	logger.Information("Packages action done")
@@ -34,9 +58,24 @@ func checkError(err error) {
 	}
 }
 
+// creates the context of a run which is cancelled by SIGTERM or an interrupt and, if configured, when the run timeout elapses
+func createRunContext(configuration *config.Config) (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	if configuration.RunTimeout <= 0 {
+		return ctx, stop
+	}
+	timeoutCtx, cancel := context.WithTimeoutCause(ctx, time.Duration(configuration.RunTimeout)*time.Second,
+		fmt.Errorf("run timeout of %d seconds exceeded", configuration.RunTimeout))
+	return timeoutCtx, func() {
+		cancel()
+		stop()
+	}
+}
+
 func initAll() {
 	service.InitAllCandidates()
 	service.InitAllDeletion()
+	service.InitAllRestore()
 	service.InitAllGitHubRest()
 }
 
@@ -1,10 +1,18 @@
 package main
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/ma-vin/packages-action/config"
+	"github.com/ma-vin/packages-action/service"
 	"github.com/ma-vin/packages-action/service/github_model"
 	"github.com/ma-vin/packages-action/testutil"
 	testutilAssert "github.com/ma-vin/testutil-go"
@@ -12,11 +20,14 @@ import (
 )
 
 func unsetEnv() {
+	// the arguments of the test binary are no command
+	os.Args = os.Args[:1]
 	os.Unsetenv(config.ENV_NAME_GITHUB_REST_API_URL)
 	os.Unsetenv(config.ENV_NAME_ORGANIZATION)
 	os.Unsetenv(config.ENV_NAME_USER)
 	os.Unsetenv(config.ENV_NAME_PACKAGE_TYPE)
 	os.Unsetenv(config.ENV_NAME_PACKAGE_NAME)
+	os.Unsetenv(config.ENV_NAME_PACKAGE_NAME_PATTERN)
 	os.Unsetenv(config.ENV_NAME_VERSION_NAME_TO_DELETE)
 	os.Unsetenv(config.ENV_NAME_DELETE_SNAPSHOTS)
 	os.Unsetenv(config.ENV_NAME_NUMBER_MAJOR_TO_KEEP)
@@ -24,6 +35,27 @@ func unsetEnv() {
 	os.Unsetenv(config.ENV_NAME_NUMBER_PATCH_TO_KEEP)
 	os.Unsetenv(config.ENV_NAME_GITHUB_TOKEN)
 	os.Unsetenv(config.ENV_NAME_DRY_RUN)
+	os.Unsetenv(config.ENV_NAME_PAGE_SIZE)
+	os.Unsetenv(config.ENV_NAME_DELETE_UNTAGGED)
+	os.Unsetenv(config.ENV_NAME_REPORT_FILE)
+	os.Unsetenv(config.ENV_NAME_STEP_SUMMARY)
+	os.Unsetenv(config.ENV_NAME_OUTPUT)
+	os.Unsetenv(config.ENV_NAME_EMPTY_PACKAGE_POLICY)
+	os.Unsetenv(config.ENV_NAME_MIN_VERSIONS_TO_KEEP)
+	os.Unsetenv(config.ENV_NAME_MAX_VERSIONS_TO_DELETE)
+	os.Unsetenv(config.ENV_NAME_FORCE)
+	os.Unsetenv(config.ENV_NAME_MODE)
+	os.Unsetenv(config.ENV_NAME_RESTORE_REPORT_FILE)
+	os.Unsetenv(config.ENV_NAME_RESTORE_VERSIONS)
+	os.Unsetenv(config.ENV_NAME_RESTORE_PACKAGE)
+	os.Unsetenv(config.ENV_NAME_CONFIG_FILE)
+	os.Unsetenv(config.ENV_NAME_PLAN_FILE)
+	os.Unsetenv(config.ENV_NAME_APP_ID)
+	os.Unsetenv(config.ENV_NAME_APP_INSTALLATION_ID)
+	os.Unsetenv(config.ENV_NAME_APP_PRIVATE_KEY)
+	os.Unsetenv(config.ENV_NAME_REPOSITORY)
+	os.Unsetenv(config.ENV_NAME_CURRENT_REPOSITORY)
+	os.Unsetenv(config.ENV_NAME_GITHUB_REPOSITORY)
 
 	os.Setenv(loggerConfig.DEFAULT_LOG_LEVEL_PROPERTY_NAME, "INFO")
 }
@@ -49,6 +81,17 @@ func createTestVersions(snapshots bool) *[]github_model.Version {
 	return &[]github_model.Version{candidateVersionOne, candidateVersionTwo, candidateVersionThree}
 }
 
+func createTestContainerVersions() *[]github_model.Version {
+	candidateVersionOne := github_model.Version{Id: 2, Name: "sha256:1111", CreatedAt: "2024-03-12T20:00:00Z", UpdatedAt: "2024-03-13T16:00:00Z",
+		Metadata: github_model.Metadata{PackageType: github_model.CONTAINER}}
+	candidateVersionTwo := github_model.Version{Id: 3, Name: "sha256:2222", CreatedAt: "2024-03-13T20:00:00Z", UpdatedAt: "2024-03-14T16:00:00Z",
+		Metadata: github_model.Metadata{PackageType: github_model.CONTAINER, Container: github_model.Container{Tags: []string{"1.0.0"}}}}
+	candidateVersionThree := github_model.Version{Id: 4, Name: "sha256:3333", CreatedAt: "2024-03-14T20:00:00Z", UpdatedAt: "2024-03-20:00:00Z",
+		Metadata: github_model.Metadata{PackageType: github_model.CONTAINER, Container: github_model.Container{Tags: []string{"2.0.0", "latest"}}}}
+
+	return &[]github_model.Version{candidateVersionOne, candidateVersionTwo, candidateVersionThree}
+}
+
 func TestMainDeleteVersionsDryRun(t *testing.T) {
 	unsetEnv()
 
@@ -90,7 +133,7 @@ func TestMainDeleteVersionsRealRun(t *testing.T) {
 	testutilAssert.AssertEquals(1, testutil.GetAllUserPackagesCounter, t, "Count of GetAllUserPackages")
 	testutilAssert.AssertEquals(1, testutil.GetUserPackageVersionsCounter, t, "Count of GetUserPackageVersions")
 	testutilAssert.AssertEquals(2, testutil.DeleteUserPackageVersionCounter, t, "Count of DeleteUserPackageVersion")
-	testutilAssert.AssertEquals(0, testutil.GetUserPackageCounter, t, "Count of GetUserPackage")
+	testutilAssert.AssertEquals(1, testutil.GetUserPackageCounter, t, "Count of GetUserPackage")
 	testutilAssert.AssertEquals(0, testutil.DeleteUserPackageCounter, t, "Count of DeleteUserPackage")
 }
 
@@ -106,6 +149,7 @@ func TestMainDeleteAllVersionsDryRun(t *testing.T) {
 	os.Setenv(config.ENV_NAME_PACKAGE_NAME, "DummyPackage")
 	os.Setenv(config.ENV_NAME_DELETE_SNAPSHOTS, "true")
 	os.Setenv(config.ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	os.Setenv(config.ENV_NAME_EMPTY_PACKAGE_POLICY, config.EMPTY_PACKAGE_DELETE_PACKAGE)
 
 	main()
 
@@ -129,16 +173,85 @@ func TestMainDeleteAllVersionsRealRun(t *testing.T) {
 	os.Setenv(config.ENV_NAME_DELETE_SNAPSHOTS, "true")
 	os.Setenv(config.ENV_NAME_GITHUB_TOKEN, "abcdef123")
 	os.Setenv(config.ENV_NAME_DRY_RUN, "false")
+	os.Setenv(config.ENV_NAME_EMPTY_PACKAGE_POLICY, config.EMPTY_PACKAGE_DELETE_PACKAGE)
 
 	main()
 
 	testutilAssert.AssertEquals(1, testutil.GetAllUserPackagesCounter, t, "Count of GetAllUserPackages")
 	testutilAssert.AssertEquals(1, testutil.GetUserPackageVersionsCounter, t, "Count of GetUserPackageVersions")
 	testutilAssert.AssertEquals(0, testutil.DeleteUserPackageVersionCounter, t, "Count of DeleteUserPackageVersion")
-	testutilAssert.AssertEquals(1, testutil.GetUserPackageCounter, t, "Count of GetUserPackage")
+	testutilAssert.AssertEquals(2, testutil.GetUserPackageCounter, t, "Count of GetUserPackage")
 	testutilAssert.AssertEquals(1, testutil.DeleteUserPackageCounter, t, "Count of DeleteUserPackage")
 }
 
+func TestMainDeleteAllVersionsKeepLatestRealRun(t *testing.T) {
+	unsetEnv()
+
+	mockServerUrl := testutil.CreateAndStartMock("Ma-Vin", config.MAVEN, "DummyPackage", createTestVersions(true), createTestPackage())
+	defer testutil.StopMock()
+
+	os.Setenv(config.ENV_NAME_GITHUB_REST_API_URL, mockServerUrl)
+	os.Setenv(config.ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(config.ENV_NAME_PACKAGE_TYPE, config.MAVEN)
+	os.Setenv(config.ENV_NAME_PACKAGE_NAME, "DummyPackage")
+	os.Setenv(config.ENV_NAME_DELETE_SNAPSHOTS, "true")
+	os.Setenv(config.ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	os.Setenv(config.ENV_NAME_DRY_RUN, "false")
+
+	main()
+
+	testutilAssert.AssertEquals(1, testutil.GetAllUserPackagesCounter, t, "Count of GetAllUserPackages")
+	testutilAssert.AssertEquals(1, testutil.GetUserPackageVersionsCounter, t, "Count of GetUserPackageVersions")
+	testutilAssert.AssertEquals(2, testutil.DeleteUserPackageVersionCounter, t, "Count of DeleteUserPackageVersion")
+	testutilAssert.AssertEquals(1, testutil.GetUserPackageCounter, t, "Count of GetUserPackage")
+	testutilAssert.AssertEquals(0, testutil.DeleteUserPackageCounter, t, "Count of DeleteUserPackage")
+}
+
+func TestMainDeleteAllVersionsFailRealRun(t *testing.T) {
+	unsetEnv()
+
+	mockServerUrl := testutil.CreateAndStartMock("Ma-Vin", config.MAVEN, "DummyPackage", createTestVersions(true), createTestPackage())
+	defer testutil.StopMock()
+
+	os.Setenv(config.ENV_NAME_GITHUB_REST_API_URL, mockServerUrl)
+	os.Setenv(config.ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(config.ENV_NAME_PACKAGE_TYPE, config.MAVEN)
+	os.Setenv(config.ENV_NAME_PACKAGE_NAME, "DummyPackage")
+	os.Setenv(config.ENV_NAME_DELETE_SNAPSHOTS, "true")
+	os.Setenv(config.ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	os.Setenv(config.ENV_NAME_DRY_RUN, "false")
+	os.Setenv(config.ENV_NAME_EMPTY_PACKAGE_POLICY, config.EMPTY_PACKAGE_FAIL)
+
+	main()
+
+	testutilAssert.AssertEquals(1, testutil.GetUserPackageVersionsCounter, t, "Count of GetUserPackageVersions")
+	testutilAssert.AssertEquals(0, testutil.DeleteUserPackageVersionCounter, t, "Count of DeleteUserPackageVersion")
+	testutilAssert.AssertEquals(0, testutil.DeleteUserPackageCounter, t, "Count of DeleteUserPackage")
+}
+
+func TestMainDeleteVersionsMaxVersionsExceededRealRun(t *testing.T) {
+	unsetEnv()
+
+	mockServerUrl := testutil.CreateAndStartMock("Ma-Vin", config.MAVEN, "DummyPackage", createTestVersions(true), createTestPackage())
+	defer testutil.StopMock()
+
+	os.Setenv(config.ENV_NAME_GITHUB_REST_API_URL, mockServerUrl)
+	os.Setenv(config.ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(config.ENV_NAME_PACKAGE_TYPE, config.MAVEN)
+	os.Setenv(config.ENV_NAME_PACKAGE_NAME, "DummyPackage")
+	os.Setenv(config.ENV_NAME_DELETE_SNAPSHOTS, "true")
+	os.Setenv(config.ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	os.Setenv(config.ENV_NAME_DRY_RUN, "false")
+	os.Setenv(config.ENV_NAME_MIN_VERSIONS_TO_KEEP, "1")
+	os.Setenv(config.ENV_NAME_MAX_VERSIONS_TO_DELETE, "1")
+
+	main()
+
+	testutilAssert.AssertEquals(1, testutil.GetUserPackageVersionsCounter, t, "Count of GetUserPackageVersions")
+	testutilAssert.AssertEquals(0, testutil.DeleteUserPackageVersionCounter, t, "Count of DeleteUserPackageVersion")
+	testutilAssert.AssertEquals(0, testutil.DeleteUserPackageCounter, t, "Count of DeleteUserPackage")
+}
+
 func TestMainNoPackageDryRun(t *testing.T) {
 	unsetEnv()
 
@@ -160,3 +273,411 @@ func TestMainNoPackageDryRun(t *testing.T) {
 	testutilAssert.AssertEquals(0, testutil.GetUserPackageCounter, t, "Count of GetUserPackage")
 	testutilAssert.AssertEquals(0, testutil.DeleteUserPackageCounter, t, "Count of DeleteUserPackage")
 }
+
+func TestMainDeleteVersionsOrganizationRealRun(t *testing.T) {
+	unsetEnv()
+
+	mockServerUrl := testutil.CreateAndStartOrganizationMock("Ma-Vin-Org", config.MAVEN, "DummyPackage", createTestVersions(false), createTestPackage())
+	defer testutil.StopMock()
+
+	os.Setenv(config.ENV_NAME_GITHUB_REST_API_URL, mockServerUrl)
+	os.Setenv(config.ENV_NAME_ORGANIZATION, "Ma-Vin-Org")
+	os.Setenv(config.ENV_NAME_PACKAGE_TYPE, config.MAVEN)
+	os.Setenv(config.ENV_NAME_PACKAGE_NAME, "DummyPackage")
+	os.Setenv(config.ENV_NAME_NUMBER_MAJOR_TO_KEEP, "1")
+	os.Setenv(config.ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	os.Setenv(config.ENV_NAME_DRY_RUN, "false")
+
+	main()
+
+	testutilAssert.AssertEquals(1, testutil.GetAllUserPackagesCounter, t, "Count of GetAllUserPackages")
+	testutilAssert.AssertEquals(1, testutil.GetUserPackageVersionsCounter, t, "Count of GetUserPackageVersions")
+	testutilAssert.AssertEquals(2, testutil.DeleteUserPackageVersionCounter, t, "Count of DeleteUserPackageVersion")
+	testutilAssert.AssertEquals(1, testutil.GetUserPackageCounter, t, "Count of GetUserPackage")
+	testutilAssert.AssertEquals(0, testutil.DeleteUserPackageCounter, t, "Count of DeleteUserPackage")
+}
+
+func TestMainDeleteVersionsMultiplePagesRealRun(t *testing.T) {
+	unsetEnv()
+
+	mockServerUrl := testutil.CreateAndStartMock("Ma-Vin", config.MAVEN, "DummyPackage", createTestVersions(false), createTestPackage())
+	defer testutil.StopMock()
+
+	os.Setenv(config.ENV_NAME_GITHUB_REST_API_URL, mockServerUrl)
+	os.Setenv(config.ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(config.ENV_NAME_PACKAGE_TYPE, config.MAVEN)
+	os.Setenv(config.ENV_NAME_PACKAGE_NAME, "DummyPackage")
+	os.Setenv(config.ENV_NAME_NUMBER_MAJOR_TO_KEEP, "1")
+	os.Setenv(config.ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	os.Setenv(config.ENV_NAME_DRY_RUN, "false")
+	os.Setenv(config.ENV_NAME_PAGE_SIZE, "1")
+
+	main()
+
+	testutilAssert.AssertEquals(1, testutil.GetAllUserPackagesCounter, t, "Count of GetAllUserPackages")
+	testutilAssert.AssertEquals(3, testutil.GetUserPackageVersionsCounter, t, "Count of GetUserPackageVersions")
+	testutilAssert.AssertEquals(2, testutil.DeleteUserPackageVersionCounter, t, "Count of DeleteUserPackageVersion")
+	testutilAssert.AssertEquals(1, testutil.GetUserPackageCounter, t, "Count of GetUserPackage")
+	testutilAssert.AssertEquals(0, testutil.DeleteUserPackageCounter, t, "Count of DeleteUserPackage")
+}
+
+func TestMainDeleteContainerVersionsRealRun(t *testing.T) {
+	unsetEnv()
+
+	mockServerUrl := testutil.CreateAndStartMock("Ma-Vin", config.CONTAINER, "DummyPackage", createTestContainerVersions(), createTestPackage())
+	defer testutil.StopMock()
+
+	os.Setenv(config.ENV_NAME_GITHUB_REST_API_URL, mockServerUrl)
+	os.Setenv(config.ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(config.ENV_NAME_PACKAGE_TYPE, config.CONTAINER)
+	os.Setenv(config.ENV_NAME_PACKAGE_NAME, "DummyPackage")
+	os.Setenv(config.ENV_NAME_DELETE_UNTAGGED, "true")
+	os.Setenv(config.ENV_NAME_NUMBER_MAJOR_TO_KEEP, "1")
+	os.Setenv(config.ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	os.Setenv(config.ENV_NAME_DRY_RUN, "false")
+
+	main()
+
+	testutilAssert.AssertEquals(1, testutil.GetAllUserPackagesCounter, t, "Count of GetAllUserPackages")
+	testutilAssert.AssertEquals(1, testutil.GetUserPackageVersionsCounter, t, "Count of GetUserPackageVersions")
+	testutilAssert.AssertEquals(2, testutil.DeleteUserPackageVersionCounter, t, "Count of DeleteUserPackageVersion")
+	testutilAssert.AssertEquals(1, testutil.GetUserPackageCounter, t, "Count of GetUserPackage")
+	testutilAssert.AssertEquals(0, testutil.DeleteUserPackageCounter, t, "Count of DeleteUserPackage")
+}
+
+func TestMainDeleteVersionsMultiplePackagesRealRun(t *testing.T) {
+	unsetEnv()
+
+	mockServerUrl := testutil.CreateAndStartMock("Ma-Vin", config.MAVEN, "DummyPackage", createTestVersions(false), createTestPackage())
+	defer testutil.StopMock()
+	testutil.AddPackageToMock("OtherDummyPackage", createTestVersions(false), &github_model.UserPackage{Id: 5, Name: "OtherDummyPackage", CreatedAt: "2024-03-12T20:00:00Z", UpdatedAt: "2024-03-20T20:00:00Z"})
+	testutil.AddPackageToMock("AnotherPackage", createTestVersions(false), &github_model.UserPackage{Id: 6, Name: "AnotherPackage", CreatedAt: "2024-03-12T20:00:00Z", UpdatedAt: "2024-03-20T20:00:00Z"})
+
+	os.Setenv(config.ENV_NAME_GITHUB_REST_API_URL, mockServerUrl)
+	os.Setenv(config.ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(config.ENV_NAME_PACKAGE_TYPE, config.MAVEN)
+	os.Setenv(config.ENV_NAME_PACKAGE_NAME_PATTERN, "*DummyPackage")
+	os.Setenv(config.ENV_NAME_NUMBER_MAJOR_TO_KEEP, "1")
+	os.Setenv(config.ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	os.Setenv(config.ENV_NAME_DRY_RUN, "false")
+
+	main()
+
+	testutilAssert.AssertEquals(1, testutil.GetAllUserPackagesCounter, t, "Count of GetAllUserPackages")
+	testutilAssert.AssertEquals(2, testutil.GetUserPackageVersionsCounter, t, "Count of GetUserPackageVersions")
+	testutilAssert.AssertEquals(4, testutil.DeleteUserPackageVersionCounter, t, "Count of DeleteUserPackageVersion")
+	testutilAssert.AssertEquals(2, testutil.GetUserPackageCounter, t, "Count of GetUserPackage")
+	testutilAssert.AssertEquals(0, testutil.DeleteUserPackageCounter, t, "Count of DeleteUserPackage")
+}
+
+func TestMainDeleteVersionsCurrentRepositoryRealRun(t *testing.T) {
+	unsetEnv()
+
+	linkedPackage := createTestPackage()
+	linkedPackage.Repository = github_model.Repository{Name: "dummy-repo", FullName: "Ma-Vin/dummy-repo"}
+	mockServerUrl := testutil.CreateAndStartMock("Ma-Vin", config.MAVEN, "DummyPackage", createTestVersions(false), linkedPackage)
+	defer testutil.StopMock()
+	testutil.AddPackageToMock("OtherDummyPackage", createTestVersions(false), &github_model.UserPackage{Id: 5, Name: "OtherDummyPackage", CreatedAt: "2024-03-12T20:00:00Z", UpdatedAt: "2024-03-20T20:00:00Z",
+		Repository: github_model.Repository{Name: "other-repo", FullName: "Ma-Vin/other-repo"}})
+
+	os.Setenv(config.ENV_NAME_GITHUB_REST_API_URL, mockServerUrl)
+	os.Setenv(config.ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(config.ENV_NAME_PACKAGE_TYPE, config.MAVEN)
+	os.Setenv(config.ENV_NAME_CURRENT_REPOSITORY, "true")
+	os.Setenv(config.ENV_NAME_GITHUB_REPOSITORY, "Ma-Vin/dummy-repo")
+	os.Setenv(config.ENV_NAME_NUMBER_MAJOR_TO_KEEP, "1")
+	os.Setenv(config.ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	os.Setenv(config.ENV_NAME_DRY_RUN, "false")
+
+	main()
+
+	testutilAssert.AssertEquals(1, testutil.GetAllUserPackagesCounter, t, "Count of GetAllUserPackages")
+	testutilAssert.AssertEquals(1, testutil.GetUserPackageVersionsCounter, t, "Count of GetUserPackageVersions")
+	testutilAssert.AssertEquals(2, testutil.DeleteUserPackageVersionCounter, t, "Count of DeleteUserPackageVersion")
+	testutilAssert.AssertEquals(0, testutil.DeleteUserPackageCounter, t, "Count of DeleteUserPackage")
+}
+
+func TestMainDeleteVersionsWithReportRealRun(t *testing.T) {
+	unsetEnv()
+
+	mockServerUrl := testutil.CreateAndStartMock("Ma-Vin", config.MAVEN, "DummyPackage", createTestVersions(false), createTestPackage())
+	defer testutil.StopMock()
+
+	reportFile := filepath.Join(t.TempDir(), "report.json")
+	summaryFile := filepath.Join(t.TempDir(), "summary.md")
+
+	os.Setenv(config.ENV_NAME_GITHUB_REST_API_URL, mockServerUrl)
+	os.Setenv(config.ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(config.ENV_NAME_PACKAGE_TYPE, config.MAVEN)
+	os.Setenv(config.ENV_NAME_PACKAGE_NAME, "DummyPackage")
+	os.Setenv(config.ENV_NAME_NUMBER_MAJOR_TO_KEEP, "1")
+	os.Setenv(config.ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	os.Setenv(config.ENV_NAME_DRY_RUN, "false")
+	os.Setenv(config.ENV_NAME_REPORT_FILE, reportFile)
+	os.Setenv(config.ENV_NAME_STEP_SUMMARY, summaryFile)
+
+	main()
+
+	testutilAssert.AssertEquals(2, testutil.DeleteUserPackageVersionCounter, t, "Count of DeleteUserPackageVersion")
+
+	content, err := os.ReadFile(reportFile)
+	testutilAssert.AssertNil(err, t, "err read report")
+	var report service.Report
+	err = json.Unmarshal(content, &report)
+	testutilAssert.AssertNil(err, t, "err unmarshal report")
+	testutilAssert.AssertFalse(report.DryRun, t, "dry run")
+	testutilAssert.AssertEquals(2, len(report.Entries), t, "number of report entries")
+	testutilAssert.AssertEquals("1.0.0", report.Entries[0].Name, t, "name of first entry")
+	testutilAssert.AssertEquals(service.RESULT_DELETED, report.Entries[0].Result, t, "result of first entry")
+	testutilAssert.AssertEquals("2.1.0", report.Entries[1].Name, t, "name of second entry")
+	testutilAssert.AssertEquals(service.RESULT_DELETED, report.Entries[1].Result, t, "result of second entry")
+
+	_, err = os.Stat(summaryFile)
+	testutilAssert.AssertNil(err, t, "err step summary")
+}
+
+func TestMainDeleteAllVersionsWithOutputsRealRun(t *testing.T) {
+	unsetEnv()
+
+	mockServerUrl := testutil.CreateAndStartMock("Ma-Vin", config.MAVEN, "DummyPackage", createTestVersions(true), createTestPackage())
+	defer testutil.StopMock()
+
+	outputFile := filepath.Join(t.TempDir(), "output")
+
+	os.Setenv(config.ENV_NAME_GITHUB_REST_API_URL, mockServerUrl)
+	os.Setenv(config.ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(config.ENV_NAME_PACKAGE_TYPE, config.MAVEN)
+	os.Setenv(config.ENV_NAME_PACKAGE_NAME, "DummyPackage")
+	os.Setenv(config.ENV_NAME_DELETE_SNAPSHOTS, "true")
+	os.Setenv(config.ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	os.Setenv(config.ENV_NAME_DRY_RUN, "false")
+	os.Setenv(config.ENV_NAME_OUTPUT, outputFile)
+	os.Setenv(config.ENV_NAME_EMPTY_PACKAGE_POLICY, config.EMPTY_PACKAGE_DELETE_PACKAGE)
+
+	main()
+
+	testutilAssert.AssertEquals(1, testutil.DeleteUserPackageCounter, t, "Count of DeleteUserPackage")
+
+	content, err := os.ReadFile(outputFile)
+	testutilAssert.AssertNil(err, t, "err read output")
+	testutilAssert.AssertEquals("deleted-count=1\ndeleted-versions=DummyPackage\nkept-versions=\npackage-deleted=true\ndry-run=false\n", string(content), t, "outputs")
+}
+
+func TestMainRestoreFromReportRealRun(t *testing.T) {
+	unsetEnv()
+
+	mockServerUrl := testutil.CreateAndStartMock("Ma-Vin", config.MAVEN, "DummyPackage", createTestVersions(true), createTestPackage())
+	defer testutil.StopMock()
+
+	reportFile := filepath.Join(t.TempDir(), "report.json")
+
+	os.Setenv(config.ENV_NAME_GITHUB_REST_API_URL, mockServerUrl)
+	os.Setenv(config.ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(config.ENV_NAME_PACKAGE_TYPE, config.MAVEN)
+	os.Setenv(config.ENV_NAME_PACKAGE_NAME, "DummyPackage")
+	os.Setenv(config.ENV_NAME_DELETE_SNAPSHOTS, "true")
+	os.Setenv(config.ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	os.Setenv(config.ENV_NAME_DRY_RUN, "false")
+	os.Setenv(config.ENV_NAME_REPORT_FILE, reportFile)
+
+	main()
+
+	testutilAssert.AssertEquals(2, testutil.DeleteUserPackageVersionCounter, t, "Count of DeleteUserPackageVersion")
+
+	os.Unsetenv(config.ENV_NAME_PACKAGE_NAME)
+	os.Unsetenv(config.ENV_NAME_REPORT_FILE)
+	os.Setenv(config.ENV_NAME_MODE, config.MODE_RESTORE)
+	os.Setenv(config.ENV_NAME_RESTORE_REPORT_FILE, reportFile)
+
+	main()
+
+	testutilAssert.AssertEquals(2, testutil.RestoreUserPackageVersionCounter, t, "Count of RestoreUserPackageVersion")
+	testutilAssert.AssertEquals(0, testutil.RestoreUserPackageCounter, t, "Count of RestoreUserPackage")
+}
+
+func TestMainRestoreVersionNamesAndPackageRealRun(t *testing.T) {
+	unsetEnv()
+
+	mockServerUrl := testutil.CreateAndStartMock("Ma-Vin", config.MAVEN, "DummyPackage", &[]github_model.Version{}, createTestPackage())
+	defer testutil.StopMock()
+	testutil.AddDeletedVersionsToMock("DummyPackage", createTestVersions(true))
+
+	os.Setenv(config.ENV_NAME_GITHUB_REST_API_URL, mockServerUrl)
+	os.Setenv(config.ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(config.ENV_NAME_PACKAGE_TYPE, config.MAVEN)
+	os.Setenv(config.ENV_NAME_PACKAGE_NAME, "DummyPackage")
+	os.Setenv(config.ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	os.Setenv(config.ENV_NAME_DRY_RUN, "false")
+	os.Setenv(config.ENV_NAME_MODE, config.MODE_RESTORE)
+	os.Setenv(config.ENV_NAME_RESTORE_VERSIONS, "1.0.0-SNAPSHOT,3.0.1-SNAPSHOT")
+	os.Setenv(config.ENV_NAME_RESTORE_PACKAGE, "true")
+
+	main()
+
+	testutilAssert.AssertEquals(1, testutil.GetDeletedUserPackageVersionsCounter, t, "Count of GetDeletedUserPackageVersions")
+	testutilAssert.AssertEquals(2, testutil.RestoreUserPackageVersionCounter, t, "Count of RestoreUserPackageVersion")
+	testutilAssert.AssertEquals(1, testutil.RestoreUserPackageCounter, t, "Count of RestoreUserPackage")
+}
+
+func TestMainCliDelete(t *testing.T) {
+	unsetEnv()
+
+	mockServerUrl := testutil.CreateAndStartMock("Ma-Vin", config.MAVEN, "DummyPackage", createTestVersions(false), createTestPackage())
+	defer testutil.StopMock()
+
+	os.Setenv(config.ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	originalArgs := os.Args
+	defer func() { os.Args = originalArgs }()
+	os.Args = []string{"packages-action", "delete", "--github-rest-api-url", mockServerUrl, "--github-user", "Ma-Vin", "--package-type", config.MAVEN,
+		"--package-name", "DummyPackage", "--number-major-to-keep", "1"}
+
+	main()
+
+	testutilAssert.AssertEquals(1, testutil.GetAllUserPackagesCounter, t, "Count of GetAllUserPackages")
+	testutilAssert.AssertEquals(1, testutil.GetUserPackageVersionsCounter, t, "Count of GetUserPackageVersions")
+	testutilAssert.AssertEquals(2, testutil.DeleteUserPackageVersionCounter, t, "Count of DeleteUserPackageVersion")
+	testutilAssert.AssertEquals(0, testutil.DeleteUserPackageCounter, t, "Count of DeleteUserPackage")
+}
+
+func TestMainCliPlan(t *testing.T) {
+	unsetEnv()
+
+	mockServerUrl := testutil.CreateAndStartMock("Ma-Vin", config.MAVEN, "DummyPackage", createTestVersions(false), createTestPackage())
+	defer testutil.StopMock()
+
+	os.Setenv(config.ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	os.Setenv(config.ENV_NAME_DRY_RUN, "false")
+	originalArgs := os.Args
+	defer func() { os.Args = originalArgs }()
+	os.Args = []string{"packages-action", "plan", "--github-rest-api-url", mockServerUrl, "--github-user", "Ma-Vin", "--package-type", config.MAVEN,
+		"--package-name", "DummyPackage", "--number-major-to-keep", "1"}
+
+	main()
+
+	testutilAssert.AssertEquals(1, testutil.GetUserPackageVersionsCounter, t, "Count of GetUserPackageVersions")
+	testutilAssert.AssertEquals(0, testutil.DeleteUserPackageVersionCounter, t, "Count of DeleteUserPackageVersion")
+}
+
+func TestMainCliList(t *testing.T) {
+	unsetEnv()
+
+	mockServerUrl := testutil.CreateAndStartMock("Ma-Vin", config.MAVEN, "DummyPackage", createTestVersions(false), createTestPackage())
+	defer testutil.StopMock()
+
+	os.Setenv(config.ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	originalArgs := os.Args
+	defer func() { os.Args = originalArgs }()
+	os.Args = []string{"packages-action", "list", "--github-rest-api-url", mockServerUrl, "--github-user", "Ma-Vin", "--package-type", config.MAVEN}
+
+	main()
+
+	testutilAssert.AssertEquals(1, testutil.GetAllUserPackagesCounter, t, "Count of GetAllUserPackages")
+	testutilAssert.AssertEquals(1, testutil.GetUserPackageVersionsCounter, t, "Count of GetUserPackageVersions")
+	testutilAssert.AssertEquals(0, testutil.DeleteUserPackageVersionCounter, t, "Count of DeleteUserPackageVersion")
+}
+
+func TestMainCliPlanAndApply(t *testing.T) {
+	unsetEnv()
+
+	mockServerUrl := testutil.CreateAndStartMock("Ma-Vin", config.MAVEN, "DummyPackage", createTestVersions(false), createTestPackage())
+	defer testutil.StopMock()
+
+	planFile := filepath.Join(t.TempDir(), "plan.json")
+	os.Setenv(config.ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	originalArgs := os.Args
+	defer func() { os.Args = originalArgs }()
+	os.Args = []string{"packages-action", "plan", "--github-rest-api-url", mockServerUrl, "--github-user", "Ma-Vin", "--package-type", config.MAVEN,
+		"--package-name", "DummyPackage", "--number-major-to-keep", "1", "--plan-file", planFile}
+
+	main()
+
+	testutilAssert.AssertEquals(0, testutil.DeleteUserPackageVersionCounter, t, "Count of DeleteUserPackageVersion after plan")
+	plan, err := service.ReadPlan(planFile)
+	testutilAssert.AssertNil(err, t, "err of plan")
+	testutilAssert.AssertEquals(2, len(plan.Packages[0].Candidates), t, "len planned candidates")
+
+	unsetEnv()
+	os.Setenv(config.ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	os.Args = []string{"packages-action", "apply", "--github-rest-api-url", mockServerUrl, "--github-user", "Ma-Vin", "--package-type", config.MAVEN,
+		"--plan-file", planFile}
+
+	main()
+
+	testutilAssert.AssertEquals(2, testutil.GetUserPackageVersionsCounter, t, "Count of GetUserPackageVersions")
+	testutilAssert.AssertEquals(2, testutil.DeleteUserPackageVersionCounter, t, "Count of DeleteUserPackageVersion after apply")
+}
+
+func TestMainDeleteVersionsMissingTokenScopeRealRun(t *testing.T) {
+	unsetEnv()
+
+	mockServerUrl := testutil.CreateAndStartMock("Ma-Vin", config.MAVEN, "DummyPackage", createTestVersions(false), createTestPackage())
+	defer testutil.StopMock()
+	testutil.SetTokenScopesOfMock("read:packages, write:packages")
+
+	os.Setenv(config.ENV_NAME_GITHUB_REST_API_URL, mockServerUrl)
+	os.Setenv(config.ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(config.ENV_NAME_PACKAGE_TYPE, config.MAVEN)
+	os.Setenv(config.ENV_NAME_PACKAGE_NAME, "DummyPackage")
+	os.Setenv(config.ENV_NAME_NUMBER_MAJOR_TO_KEEP, "1")
+	os.Setenv(config.ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	os.Setenv(config.ENV_NAME_DRY_RUN, "false")
+
+	main()
+
+	testutilAssert.AssertEquals(1, testutil.GetUserPackageCounter, t, "Count of GetUserPackage")
+	testutilAssert.AssertEquals(0, testutil.DeleteUserPackageVersionCounter, t, "Count of DeleteUserPackageVersion")
+	testutilAssert.AssertEquals(0, testutil.DeleteUserPackageCounter, t, "Count of DeleteUserPackage")
+}
+
+func TestMainDeleteVersionsWithTokenScopeRealRun(t *testing.T) {
+	unsetEnv()
+
+	mockServerUrl := testutil.CreateAndStartMock("Ma-Vin", config.MAVEN, "DummyPackage", createTestVersions(false), createTestPackage())
+	defer testutil.StopMock()
+	testutil.SetTokenScopesOfMock("read:packages, delete:packages")
+
+	os.Setenv(config.ENV_NAME_GITHUB_REST_API_URL, mockServerUrl)
+	os.Setenv(config.ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(config.ENV_NAME_PACKAGE_TYPE, config.MAVEN)
+	os.Setenv(config.ENV_NAME_PACKAGE_NAME, "DummyPackage")
+	os.Setenv(config.ENV_NAME_NUMBER_MAJOR_TO_KEEP, "1")
+	os.Setenv(config.ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	os.Setenv(config.ENV_NAME_DRY_RUN, "false")
+
+	main()
+
+	testutilAssert.AssertEquals(1, testutil.GetUserPackageCounter, t, "Count of GetUserPackage")
+	testutilAssert.AssertEquals(2, testutil.DeleteUserPackageVersionCounter, t, "Count of DeleteUserPackageVersion")
+}
+
+func TestMainDeleteVersionsGitHubAppRealRun(t *testing.T) {
+	unsetEnv()
+
+	mockServerUrl := testutil.CreateAndStartMock("Ma-Vin", config.MAVEN, "DummyPackage", createTestVersions(false), createTestPackage())
+	defer testutil.StopMock()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	testutilAssert.AssertNil(err, t, "generate key")
+	testutil.AddAppInstallationToMock("12345", 42, &key.PublicKey, time.Hour)
+	keyFile := filepath.Join(t.TempDir(), "app.pem")
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	testutilAssert.AssertNil(os.WriteFile(keyFile, pemKey, 0600), t, "write key")
+
+	os.Setenv(config.ENV_NAME_GITHUB_REST_API_URL, mockServerUrl)
+	os.Setenv(config.ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(config.ENV_NAME_PACKAGE_TYPE, config.MAVEN)
+	os.Setenv(config.ENV_NAME_PACKAGE_NAME, "DummyPackage")
+	os.Setenv(config.ENV_NAME_NUMBER_MAJOR_TO_KEEP, "1")
+	os.Setenv(config.ENV_NAME_APP_ID, "12345")
+	os.Setenv(config.ENV_NAME_APP_INSTALLATION_ID, "42")
+	os.Setenv(config.ENV_NAME_APP_PRIVATE_KEY, keyFile)
+	os.Setenv(config.ENV_NAME_DRY_RUN, "false")
+
+	main()
+
+	testutilAssert.AssertEquals(1, testutil.CreateInstallationTokenCounter, t, "Count of CreateInstallationToken")
+	testutilAssert.AssertEquals(2, testutil.DeleteUserPackageVersionCounter, t, "Count of DeleteUserPackageVersion")
+	for _, authorization := range testutil.GetRequestAuthorizations() {
+		testutilAssert.AssertEquals("Bearer installation-token-1", authorization, t, "authorization")
+	}
+}
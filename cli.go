@@ -0,0 +1,190 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/ma-vin/packages-action/config"
+)
+
+const (
+	// lists packages and their versions
+	COMMAND_LIST = "list"
+	// determines the candidates to delete without deleting them
+	COMMAND_PLAN = "plan"
+	// deletes the candidates
+	COMMAND_DELETE = "delete"
+	// restores deleted versions and packages
+	COMMAND_RESTORE = "restore"
+	// deletes exactly the candidates of a plan file
+	COMMAND_APPLY = "apply"
+	// prints the usage
+	COMMAND_HELP = "help"
+)
+
+var commands = []string{COMMAND_LIST, COMMAND_PLAN, COMMAND_DELETE, COMMAND_RESTORE, COMMAND_APPLY, COMMAND_HELP}
+
+// flags which are handled like the command help if they are given instead of a command
+var helpFlags = []string{"-h", "--help", "-help"}
+
+// a command line flag which sets the environment variable of a configuration value
+type cliFlag struct {
+	envName string
+	usage   string
+	isBool  bool
+}
+
+var cliFlags = []cliFlag{
+	{config.ENV_NAME_CONFIG_FILE, "path of a yaml or json configuration file", false},
+	{config.ENV_NAME_GITHUB_REST_API_URL, "url of the GitHub rest api", false},
+	{config.ENV_NAME_ORGANIZATION, "organization which owns the packages", false},
+	{config.ENV_NAME_USER, "user who owns the packages", false},
+	{config.ENV_NAME_PACKAGE_TYPE, "type of the packages", false},
+	{config.ENV_NAME_PACKAGE_NAME, "comma separated names of the packages", false},
+	{config.ENV_NAME_PACKAGE_NAME_PATTERN, "pattern of the package names", false},
+	{config.ENV_NAME_REPOSITORY, "full name owner/name of the repository the packages are linked to", false},
+	{config.ENV_NAME_CURRENT_REPOSITORY, "only handle packages linked to the repository of " + config.ENV_NAME_GITHUB_REPOSITORY, true},
+	{config.ENV_NAME_GITHUB_TOKEN, "token to access GitHub. Prefer the environment variable " + config.ENV_NAME_GITHUB_TOKEN, false},
+	{config.ENV_NAME_APP_ID, "id or client id of the GitHub App to access GitHub instead of a token", false},
+	{config.ENV_NAME_APP_INSTALLATION_ID, "id of the installation of the GitHub App", false},
+	{config.ENV_NAME_APP_PRIVATE_KEY, "path of the PEM file of the private key of the GitHub App", false},
+	{config.ENV_NAME_VERSION_NAME_TO_DELETE, "name of a version to delete", false},
+	{config.ENV_NAME_DELETE_SNAPSHOTS, "delete snapshot versions", true},
+	{config.ENV_NAME_NUMBER_MAJOR_TO_KEEP, "number of major versions to keep", false},
+	{config.ENV_NAME_NUMBER_MINOR_TO_KEEP, "number of minor versions to keep of each major version", false},
+	{config.ENV_NAME_NUMBER_PATCH_TO_KEEP, "number of patch versions to keep of each minor version", false},
+	{config.ENV_NAME_DELETE_UNTAGGED, "delete untagged container versions", true},
+	{config.ENV_NAME_TAG_PATTERN_TO_DELETE, "regular expression of container tags to delete", false},
+	{config.ENV_NAME_PROTECTED_TAGS, "comma separated container tags which are never deleted", false},
+	{config.ENV_NAME_MAX_AGE_SNAPSHOTS, "maximum age of snapshots in days", false},
+	{config.ENV_NAME_MAX_AGE_RELEASES, "maximum age of releases in days", false},
+	{config.ENV_NAME_MIN_AGE, "minimum age in days of versions to delete", false},
+	{config.ENV_NAME_NUMBER_NEWEST_TO_KEEP, "number of newest releases to keep", false},
+	{config.ENV_NAME_VERSION_PATTERNS_TO_DELETE, "comma separated patterns of versions to delete", false},
+	{config.ENV_NAME_VERSION_PATTERNS_TO_KEEP, "comma separated patterns of versions to keep", false},
+	{config.ENV_NAME_EMPTY_PACKAGE_POLICY, "policy if all versions of a package are to delete", false},
+	{config.ENV_NAME_MIN_VERSIONS_TO_KEEP, "number of newest versions of each package to keep", false},
+	{config.ENV_NAME_MAX_VERSIONS_TO_DELETE, "maximum number of versions to delete", false},
+	{config.ENV_NAME_FORCE, "delete even if the maximum number of versions to delete is exceeded", true},
+	{config.ENV_NAME_DRY_RUN, "only log what would be done", true},
+	{config.ENV_NAME_DEBUG, "log debug messages", true},
+	{config.ENV_NAME_TIMEOUT, "timeout of rest calls in seconds", false},
+	{config.ENV_NAME_PAGE_SIZE, "page size of rest calls", false},
+	{config.ENV_NAME_MAX_RETRIES, "maximum number of retries of rest calls", false},
+	{config.ENV_NAME_RETRY_MAX_WAIT, "maximum wait in seconds between retries", false},
+	{config.ENV_NAME_MAX_CONCURRENT_DELETIONS, "maximum number of concurrent deletions", false},
+	{config.ENV_NAME_DELETION_DELAY, "delay in milliseconds between deletions", false},
+	{config.ENV_NAME_REPORT_FILE, "path of the json report", false},
+	{config.ENV_NAME_STEP_SUMMARY, "path of the markdown summary", false},
+	{config.ENV_NAME_OUTPUT, "path of the outputs file", false},
+	{config.ENV_NAME_RESTORE_REPORT_FILE, "path of a json report whose deletions are restored", false},
+	{config.ENV_NAME_RESTORE_VERSIONS, "comma separated ids or names of versions to restore", false},
+	{config.ENV_NAME_RESTORE_PACKAGE, "restore the packages themselves", true},
+	{config.ENV_NAME_PLAN_FILE, "path of the plan file which is written by plan and deleted by apply", false},
+	{config.ENV_NAME_RUN_TIMEOUT, "overall deadline of the run in seconds", false},
+}
+
+// flag value which sets an environment variable
+type envFlagValue struct {
+	envName string
+	isBool  bool
+}
+
+func (v *envFlagValue) String() string {
+	return ""
+}
+
+func (v *envFlagValue) Set(value string) error {
+	return os.Setenv(v.envName, value)
+}
+
+func (v *envFlagValue) IsBoolFlag() bool {
+	return v.isBool
+}
+
+// Applies the command and flags of the command line arguments to the environment variables of the configuration.
+// Without arguments the configuration is read from the environment only, as at GitHub actions. An unknown command is an error,
+// so a mistyped one does not run with the environment only. The boolean result indicates whether the run is to stop, because only the usage was printed
+func applyCommand(args []string, output io.Writer) (bool, error) {
+	if len(args) == 0 {
+		return false, nil
+	}
+	command := args[0]
+	if slices.Contains(helpFlags, command) {
+		command = COMMAND_HELP
+	}
+	if !slices.Contains(commands, command) {
+		printUsage(output, createFlagSet(COMMAND_HELP, output))
+		return false, fmt.Errorf("unknown command: %s", command)
+	}
+	if command == COMMAND_HELP {
+		printUsage(output, createFlagSet(command, output))
+		return true, nil
+	}
+
+	flagSet := createFlagSet(command, output)
+	flagSet.Usage = func() { printUsage(output, flagSet) }
+	if err := flagSet.Parse(args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return true, nil
+		}
+		return false, err
+	}
+	if flagSet.NArg() > 0 {
+		return false, fmt.Errorf("unexpected arguments: %s", strings.Join(flagSet.Args(), " "))
+	}
+
+	setFlags := map[string]bool{}
+	flagSet.Visit(func(f *flag.Flag) { setFlags[f.Name] = true })
+	dryRunSet := setFlags[toFlagName(config.ENV_NAME_DRY_RUN)]
+
+	switch command {
+	case COMMAND_LIST:
+		os.Setenv(config.ENV_NAME_MODE, config.MODE_LIST)
+	case COMMAND_PLAN:
+		os.Setenv(config.ENV_NAME_MODE, config.MODE_DELETE)
+		os.Setenv(config.ENV_NAME_DRY_RUN, "true")
+	case COMMAND_DELETE, COMMAND_RESTORE, COMMAND_APPLY:
+		os.Setenv(config.ENV_NAME_MODE, command)
+		if !dryRunSet {
+			os.Setenv(config.ENV_NAME_DRY_RUN, "false")
+		}
+	}
+	return false, nil
+}
+
+// creates the flag set of a command with a flag for each configuration value
+func createFlagSet(command string, output io.Writer) *flag.FlagSet {
+	flagSet := flag.NewFlagSet(command, flag.ContinueOnError)
+	flagSet.SetOutput(output)
+	for _, f := range cliFlags {
+		flagSet.Var(&envFlagValue{f.envName, f.isBool}, toFlagName(f.envName), f.usage)
+	}
+	return flagSet
+}
+
+// maps the name of an environment variable to the one of a flag, e.g. PACKAGE_NAME to package-name
+func toFlagName(envName string) string {
+	return strings.ReplaceAll(strings.ToLower(envName), "_", "-")
+}
+
+// prints the usage of the commands and their flags
+func printUsage(output io.Writer, flagSet *flag.FlagSet) {
+	fmt.Fprintln(output, "Usage: packages-action <command> [flags]")
+	fmt.Fprintln(output)
+	fmt.Fprintln(output, "Commands:")
+	fmt.Fprintln(output, "  list     lists the packages and their versions")
+	fmt.Fprintln(output, "  plan     logs the versions and packages which would be deleted and writes them to --plan-file")
+	fmt.Fprintln(output, "  delete   deletes the versions and packages, unless --dry-run is set")
+	fmt.Fprintln(output, "  restore  restores deleted versions and packages, unless --dry-run is set")
+	fmt.Fprintln(output, "  apply    deletes exactly the versions and packages of --plan-file, unless their package changed")
+	fmt.Fprintln(output, "  help     prints this usage")
+	fmt.Fprintln(output)
+	fmt.Fprintln(output, "Flags override the environment variables of the same name in upper case, e.g. --package-name and PACKAGE_NAME:")
+	flagSet.PrintDefaults()
+}
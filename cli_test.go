@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ma-vin/packages-action/config"
+	testutilAssert "github.com/ma-vin/testutil-go"
+)
+
+func TestApplyCommandWithoutCommand(t *testing.T) {
+	unsetEnv()
+
+	stop, err := applyCommand([]string{}, &bytes.Buffer{})
+
+	testutilAssert.AssertNil(err, t, "err")
+	testutilAssert.AssertFalse(stop, t, "stop")
+	testutilAssert.AssertEquals("", os.Getenv(config.ENV_NAME_MODE), t, "mode")
+}
+
+func TestApplyCommandDelete(t *testing.T) {
+	unsetEnv()
+
+	stop, err := applyCommand([]string{"delete", "--github-user", "Ma-Vin", "--package-name=DummyPackage", "--delete-snapshots"}, &bytes.Buffer{})
+
+	testutilAssert.AssertNil(err, t, "err")
+	testutilAssert.AssertFalse(stop, t, "stop")
+	testutilAssert.AssertEquals(config.MODE_DELETE, os.Getenv(config.ENV_NAME_MODE), t, "mode")
+	testutilAssert.AssertEquals("false", os.Getenv(config.ENV_NAME_DRY_RUN), t, "dry run")
+	testutilAssert.AssertEquals("Ma-Vin", os.Getenv(config.ENV_NAME_USER), t, "user")
+	testutilAssert.AssertEquals("DummyPackage", os.Getenv(config.ENV_NAME_PACKAGE_NAME), t, "package name")
+	testutilAssert.AssertEquals("true", os.Getenv(config.ENV_NAME_DELETE_SNAPSHOTS), t, "delete snapshots")
+}
+
+func TestApplyCommandDeleteDryRun(t *testing.T) {
+	unsetEnv()
+
+	stop, err := applyCommand([]string{"delete", "--dry-run"}, &bytes.Buffer{})
+
+	testutilAssert.AssertNil(err, t, "err")
+	testutilAssert.AssertFalse(stop, t, "stop")
+	testutilAssert.AssertEquals("true", os.Getenv(config.ENV_NAME_DRY_RUN), t, "dry run")
+}
+
+func TestApplyCommandPlan(t *testing.T) {
+	unsetEnv()
+
+	stop, err := applyCommand([]string{"plan", "--dry-run=false"}, &bytes.Buffer{})
+
+	testutilAssert.AssertNil(err, t, "err")
+	testutilAssert.AssertFalse(stop, t, "stop")
+	testutilAssert.AssertEquals(config.MODE_DELETE, os.Getenv(config.ENV_NAME_MODE), t, "mode")
+	testutilAssert.AssertEquals("true", os.Getenv(config.ENV_NAME_DRY_RUN), t, "dry run")
+}
+
+func TestApplyCommandListAndRestore(t *testing.T) {
+	unsetEnv()
+
+	_, err := applyCommand([]string{"list"}, &bytes.Buffer{})
+
+	testutilAssert.AssertNil(err, t, "err list")
+	testutilAssert.AssertEquals(config.MODE_LIST, os.Getenv(config.ENV_NAME_MODE), t, "mode list")
+
+	_, err = applyCommand([]string{"restore", "--restore-versions", "1.0.0"}, &bytes.Buffer{})
+
+	testutilAssert.AssertNil(err, t, "err restore")
+	testutilAssert.AssertEquals(config.MODE_RESTORE, os.Getenv(config.ENV_NAME_MODE), t, "mode restore")
+	testutilAssert.AssertEquals("1.0.0", os.Getenv(config.ENV_NAME_RESTORE_VERSIONS), t, "restore versions")
+}
+
+func TestApplyCommandUnknownFlag(t *testing.T) {
+	unsetEnv()
+
+	stop, err := applyCommand([]string{"delete", "--unknown"}, &bytes.Buffer{})
+
+	testutilAssert.AssertNotNil(err, t, "err")
+	testutilAssert.AssertFalse(stop, t, "stop")
+}
+
+func TestApplyCommandUnexpectedArgument(t *testing.T) {
+	unsetEnv()
+
+	_, err := applyCommand([]string{"delete", "DummyPackage"}, &bytes.Buffer{})
+
+	testutilAssert.AssertNotNil(err, t, "err")
+	testutilAssert.AssertEquals("unexpected arguments: DummyPackage", err.Error(), t, "error message")
+}
+
+func TestApplyCommandHelp(t *testing.T) {
+	unsetEnv()
+	output := bytes.Buffer{}
+
+	stop, err := applyCommand([]string{"help"}, &output)
+
+	testutilAssert.AssertNil(err, t, "err")
+	testutilAssert.AssertTrue(stop, t, "stop")
+	testutilAssert.AssertTrue(strings.Contains(output.String(), "-package-name"), t, "usage contains package name flag")
+	testutilAssert.AssertTrue(strings.Contains(output.String(), "-restore-package"), t, "usage contains restore package flag")
+}
+
+func TestApplyCommandUnknownCommand(t *testing.T) {
+	unsetEnv()
+	output := bytes.Buffer{}
+
+	stop, err := applyCommand([]string{"delet", "--package-name", "DummyPackage"}, &output)
+
+	testutilAssert.AssertNotNil(err, t, "err")
+	testutilAssert.AssertEquals("unknown command: delet", err.Error(), t, "error message")
+	testutilAssert.AssertFalse(stop, t, "stop")
+	testutilAssert.AssertTrue(strings.Contains(output.String(), "Usage: packages-action"), t, "usage")
+	testutilAssert.AssertEquals("", os.Getenv(config.ENV_NAME_MODE), t, "mode")
+	testutilAssert.AssertEquals("", os.Getenv(config.ENV_NAME_PACKAGE_NAME), t, "package name")
+}
+
+func TestApplyCommandFlagWithoutCommand(t *testing.T) {
+	unsetEnv()
+	output := bytes.Buffer{}
+
+	stop, err := applyCommand([]string{"--package-name", "DummyPackage"}, &output)
+
+	testutilAssert.AssertNotNil(err, t, "err")
+	testutilAssert.AssertEquals("unknown command: --package-name", err.Error(), t, "error message")
+	testutilAssert.AssertFalse(stop, t, "stop")
+	testutilAssert.AssertEquals("", os.Getenv(config.ENV_NAME_PACKAGE_NAME), t, "package name")
+}
+
+func TestApplyCommandHelpFlagWithoutCommand(t *testing.T) {
+	for _, helpFlag := range []string{"-h", "--help"} {
+		unsetEnv()
+		output := bytes.Buffer{}
+
+		stop, err := applyCommand([]string{helpFlag}, &output)
+
+		testutilAssert.AssertNil(err, t, "err of "+helpFlag)
+		testutilAssert.AssertTrue(stop, t, "stop of "+helpFlag)
+		testutilAssert.AssertTrue(strings.Contains(output.String(), "Usage: packages-action"), t, "usage of "+helpFlag)
+	}
+}
+
+func TestApplyCommandFlagHelp(t *testing.T) {
+	unsetEnv()
+	output := bytes.Buffer{}
+
+	stop, err := applyCommand([]string{"delete", "-h"}, &output)
+
+	testutilAssert.AssertNil(err, t, "err")
+	testutilAssert.AssertTrue(stop, t, "stop")
+	testutilAssert.AssertTrue(strings.Contains(output.String(), "Usage: packages-action"), t, "usage")
+}
+
+func TestApplyCommandApply(t *testing.T) {
+	unsetEnv()
+
+	_, err := applyCommand([]string{"apply", "--plan-file", "plan.json"}, &bytes.Buffer{})
+
+	testutilAssert.AssertNil(err, t, "err")
+	testutilAssert.AssertEquals(config.MODE_APPLY, os.Getenv(config.ENV_NAME_MODE), t, "mode")
+	testutilAssert.AssertEquals("false", os.Getenv(config.ENV_NAME_DRY_RUN), t, "dry run")
+	testutilAssert.AssertEquals("plan.json", os.Getenv(config.ENV_NAME_PLAN_FILE), t, "plan file")
+}
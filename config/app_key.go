@@ -0,0 +1,56 @@
+package config
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"strings"
+)
+
+// begin of the content of a PEM file. Values without it are paths of PEM files
+const pemBegin = "-----BEGIN"
+
+// Reads the private key of a GitHub App which is given either by its PEM content or by the path of its PEM file.
+// Keys which can not be read or parsed are collected as problem without their content
+func readAppPrivateKey(value string, errs *ValidationErrors) string {
+	if value == "" {
+		return ""
+	}
+	pemKey := value
+	if !strings.Contains(value, pemBegin) {
+		content, err := os.ReadFile(value)
+		if err != nil {
+			errs.add(ENV_NAME_APP_PRIVATE_KEY, value, expectedPrivateKey)
+			return ""
+		}
+		pemKey = string(content)
+	}
+	if _, err := ParseAppPrivateKey(pemKey); err != nil {
+		errs.add(ENV_NAME_APP_PRIVATE_KEY, "***", expectedPrivateKey)
+		return ""
+	}
+	return pemKey
+}
+
+// Parses the RSA private key of a GitHub App in PEM format. Both PKCS #1, as downloaded from GitHub, and PKCS #8 are supported.
+// Line breaks which are escaped, like at single line environment variables, are unescaped before
+func ParseAppPrivateKey(pemKey string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(strings.ReplaceAll(pemKey, `\n`, "\n")))
+	if block == nil {
+		return nil, errors.New("the private key of the GitHub App is not in PEM format")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.New("the private key of the GitHub App can not be parsed")
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("the private key of the GitHub App is not an RSA key")
+	}
+	return rsaKey, nil
+}
@@ -1,7 +1,6 @@
 package config
 
 import (
-	"errors"
 	"os"
 	"strconv"
 	"strings"
@@ -12,27 +11,99 @@ import (
 const (
 	// package type for maven
 	MAVEN string = "maven"
+	// package type for npm
+	NPM string = "npm"
+	// package type for container images
+	CONTAINER string = "container"
+	// package type for docker images
+	DOCKER string = "docker"
 	// package type for a not supported or unknown type
 	UNKNOWN string = "unkown"
 
 	// Input action variables get a prefix at GitHub
-	ENV_GITHUB_PREFIX               string = "INPUT_"
-	ENV_NAME_GITHUB_REST_API_URL    string = "GITHUB_REST_API_URL"
-	ENV_NAME_ORGANIZATION           string = "GITHUB_ORGANIZATION"
-	ENV_NAME_USER                   string = "GITHUB_USER"
-	ENV_NAME_PACKAGE_TYPE           string = "PACKAGE_TYPE"
-	ENV_NAME_PACKAGE_NAME           string = "PACKAGE_NAME"
-	ENV_NAME_VERSION_NAME_TO_DELETE string = "VERSION_NAME_TO_DELETE"
-	ENV_NAME_DELETE_SNAPSHOTS       string = "DELETE_SNAPSHOTS"
-	ENV_NAME_NUMBER_MAJOR_TO_KEEP   string = "NUMBER_MAJOR_TO_KEEP"
-	ENV_NAME_NUMBER_MINOR_TO_KEEP   string = "NUMBER_MINOR_TO_KEEP"
-	ENV_NAME_NUMBER_PATCH_TO_KEEP   string = "NUMBER_PATCH_TO_KEEP"
-	ENV_NAME_GITHUB_TOKEN           string = "GITHUB_TOKEN"
-	ENV_NAME_DRY_RUN                string = "DRY_RUN"
-	ENV_NAME_DEBUG                  string = "DEBUG_LOGS"
-	ENV_NAME_TIMEOUT                string = "REST_TIMEOUT"
+	ENV_GITHUB_PREFIX                   string = "INPUT_"
+	ENV_NAME_GITHUB_REST_API_URL        string = "GITHUB_REST_API_URL"
+	ENV_NAME_ORGANIZATION               string = "GITHUB_ORGANIZATION"
+	ENV_NAME_USER                       string = "GITHUB_USER"
+	ENV_NAME_PACKAGE_TYPE               string = "PACKAGE_TYPE"
+	ENV_NAME_PACKAGE_NAME               string = "PACKAGE_NAME"
+	ENV_NAME_PACKAGE_NAME_PATTERN       string = "PACKAGE_NAME_PATTERN"
+	ENV_NAME_VERSION_NAME_TO_DELETE     string = "VERSION_NAME_TO_DELETE"
+	ENV_NAME_DELETE_SNAPSHOTS           string = "DELETE_SNAPSHOTS"
+	ENV_NAME_NUMBER_MAJOR_TO_KEEP       string = "NUMBER_MAJOR_TO_KEEP"
+	ENV_NAME_NUMBER_MINOR_TO_KEEP       string = "NUMBER_MINOR_TO_KEEP"
+	ENV_NAME_NUMBER_PATCH_TO_KEEP       string = "NUMBER_PATCH_TO_KEEP"
+	ENV_NAME_GITHUB_TOKEN               string = "GITHUB_TOKEN"
+	ENV_NAME_DRY_RUN                    string = "DRY_RUN"
+	ENV_NAME_DEBUG                      string = "DEBUG_LOGS"
+	ENV_NAME_TIMEOUT                    string = "REST_TIMEOUT"
+	ENV_NAME_PAGE_SIZE                  string = "PAGE_SIZE"
+	ENV_NAME_MAX_RETRIES                string = "MAX_RETRIES"
+	ENV_NAME_RETRY_MAX_WAIT             string = "RETRY_MAX_WAIT"
+	ENV_NAME_MAX_CONCURRENT_DELETIONS   string = "MAX_CONCURRENT_DELETIONS"
+	ENV_NAME_DELETION_DELAY             string = "DELETION_DELAY"
+	ENV_NAME_REPORT_FILE                string = "REPORT_FILE"
+	ENV_NAME_STEP_SUMMARY               string = "GITHUB_STEP_SUMMARY"
+	ENV_NAME_OUTPUT                     string = "GITHUB_OUTPUT"
+	ENV_NAME_DELETE_UNTAGGED            string = "DELETE_UNTAGGED"
+	ENV_NAME_TAG_PATTERN_TO_DELETE      string = "TAG_PATTERN_TO_DELETE"
+	ENV_NAME_PROTECTED_TAGS             string = "PROTECTED_TAGS"
+	ENV_NAME_MAX_AGE_SNAPSHOTS          string = "MAX_AGE_SNAPSHOTS"
+	ENV_NAME_MAX_AGE_RELEASES           string = "MAX_AGE_RELEASES"
+	ENV_NAME_MIN_AGE                    string = "MIN_AGE"
+	ENV_NAME_NUMBER_NEWEST_TO_KEEP      string = "NUMBER_NEWEST_RELEASES_TO_KEEP"
+	ENV_NAME_VERSION_PATTERNS_TO_DELETE string = "VERSION_PATTERNS_TO_DELETE"
+	ENV_NAME_VERSION_PATTERNS_TO_KEEP   string = "VERSION_PATTERNS_TO_KEEP"
+	ENV_NAME_EMPTY_PACKAGE_POLICY       string = "EMPTY_PACKAGE_POLICY"
+	ENV_NAME_MIN_VERSIONS_TO_KEEP       string = "MIN_VERSIONS_TO_KEEP"
+	ENV_NAME_MAX_VERSIONS_TO_DELETE     string = "MAX_VERSIONS_TO_DELETE"
+	ENV_NAME_FORCE                      string = "FORCE"
+	ENV_NAME_MODE                       string = "MODE"
+	ENV_NAME_RESTORE_REPORT_FILE        string = "RESTORE_REPORT_FILE"
+	ENV_NAME_RESTORE_VERSIONS           string = "RESTORE_VERSIONS"
+	ENV_NAME_RESTORE_PACKAGE            string = "RESTORE_PACKAGE"
+	ENV_NAME_CONFIG_FILE                string = "CONFIG_FILE"
+	ENV_NAME_PLAN_FILE                  string = "PLAN_FILE"
+	ENV_NAME_RUN_TIMEOUT                string = "RUN_TIMEOUT"
+	ENV_NAME_APP_ID                     string = "APP_ID"
+	ENV_NAME_APP_INSTALLATION_ID        string = "APP_INSTALLATION_ID"
+	ENV_NAME_APP_PRIVATE_KEY            string = "APP_PRIVATE_KEY"
+	ENV_NAME_REPOSITORY                 string = "REPOSITORY"
+	ENV_NAME_CURRENT_REPOSITORY         string = "CURRENT_REPOSITORY"
+	ENV_NAME_GITHUB_REPOSITORY          string = "GITHUB_REPOSITORY"
+
+	// mode to delete versions or packages
+	MODE_DELETE string = "delete"
+	// mode to restore deleted versions or packages
+	MODE_RESTORE string = "restore"
+	// mode to list packages and their versions without deleting anything
+	MODE_LIST string = "list"
+	// mode to delete exactly the candidates of a plan file
+	MODE_APPLY string = "apply"
+
+	// empty package policy to delete the whole package if all of its versions are to delete
+	EMPTY_PACKAGE_DELETE_PACKAGE string = "delete-package"
+	// empty package policy to keep the newest version if all versions of a package are to delete
+	EMPTY_PACKAGE_KEEP_LATEST string = "keep-latest"
+	// empty package policy to abort the run if all versions of a package are to delete
+	EMPTY_PACKAGE_FAIL string = "fail"
+
+	// prefix of version patterns which are regular expressions instead of globs
+	REGEX_PATTERN_PREFIX string = "regex:"
 
 	gitHubUrl string = "https://api.github.com"
+	// maximum number of elements per page which is supported by GitHub rest api
+	maxPageSize int = 100
+	// default number of retries of a failed rest call
+	defaultMaxRetries int = 3
+	// maximum number of retries of a failed rest call
+	maxRetries int = 20
+	// default maximum number of seconds to wait before retrying a rest call
+	defaultRetryMaxWait int = 60
+	// default number of deletions which are executed in parallel
+	defaultMaxConcurrentDeletions int = 5
+	// tag of container images which is protected by default
+	latestTag string = "latest"
 )
 
 // structure to hold configuration of the action
@@ -45,8 +116,14 @@ type Config struct {
 	User string
 	// package type whiche are to handle (not nil)
 	PackageType string
-	// name of the package (not nil)
+	// name of the package which is to handle. If there are multiple package names, this is the one currently handled
 	PackageName string
+	// names of all packages which are to handle (Either this or package name pattern has to be set)
+	PackageNames []string
+	// glob or, with prefix "regex:", regular expression pattern of package names which are to handle
+	PackageNamePattern string
+	// full name owner/name of the repository the handled packages have to be linked to. Without package names and pattern, all of its packages are handled
+	Repository string
 	// name of a version to delete. Number "x" versions to keep will be ignored
 	VersionNameToDelete string
 	// indicator whether to delete snapshots or not. snapshot are not assumed to ba a major, minor or patch version
@@ -57,14 +134,78 @@ type Config struct {
 	NumberOfMinorVersionsToKeep int
 	// Number patch versions to keep
 	NumberOfPatchVersionsToKeep int
-	// token which is to use to authenticate against github rest api (not nil)
+	// token which is to use to authenticate against github rest api. Not required if a GitHub App is configured
 	GithubToken string
+	// id or client id of the GitHub App whose installation token is used instead of the token
+	AppId string
+	// id of the installation of the GitHub App at the owner of the packages
+	AppInstallationId int
+	// private key of the GitHub App in PEM format, which is used to sign the JWT of the app
+	AppPrivateKey string
 	// Indicator wether to run application without deletion or not. Default true (No deletetion)
 	DryRun bool
 	// Indicator wether to log more information
 	Debug bool
 	// Timeout in seconds for rest calls
 	Timeout int
+	// Number of elements per page at rest calls which are paginated
+	PageSize int
+	// Number of retries of rest calls which failed because of rate limits, server or network errors
+	MaxRetries int
+	// Maximum number of seconds to wait before a retry. If GitHub requests a longer waiting, the rest call fails
+	RetryMaxWait int
+	// Maximum number of deletions which are executed in parallel
+	MaxConcurrentDeletions int
+	// Number of milliseconds to wait after each deletion before the next one of the same worker
+	DeletionDelay int
+	// Path of the file where to write the json report to
+	ReportFile string
+	// Path of the GitHub step summary file where to append the markdown report to
+	StepSummaryFile string
+	// Path of the GitHub output file where to append the action outputs to
+	OutputFile string
+	// indicator whether to delete container versions without any tag or not
+	DeleteUntagged bool
+	// regular expression of container tags to delete. A version is only deleted if all of its tags match
+	TagPatternToDelete string
+	// container tags whose versions are never deleted
+	ProtectedTags []string
+	// Number of days after the last update of a snapshot when it is to delete
+	MaxAgeOfSnapshots int
+	// Number of days after the last update of a release when it is to delete, unless it is one of the newest releases to keep
+	MaxAgeOfReleases int
+	// Number of days after the last update of a version before it may be deleted. Only a concrete version name to delete ignores this
+	MinAge int
+	// Number of newest releases which are not deleted because of their age
+	NumberOfNewestReleasesToKeep int
+	// glob or, with prefix "regex:", regular expression patterns of version names to delete
+	VersionPatternsToDelete []string
+	// glob or, with prefix "regex:", regular expression patterns of version names which are never deleted
+	VersionPatternsToKeep []string
+	// policy how to handle a package whose versions are all to delete: delete the package, keep the newest version or fail
+	EmptyPackagePolicy string
+	// Number of newest versions of each package which are never deleted, whichever rule applies
+	MinVersionsToKeep int
+	// Maximum number of versions which may be deleted at one run. If there are more candidates, the run fails unless it is forced
+	MaxVersionsToDelete int
+	// Indicator whether to delete even if there are more candidates than the maximum number of versions to delete
+	Force bool
+	// mode of the run: either delete, restore, list or apply
+	Mode string
+	// Path of the json report of a previous run whose deleted versions and packages are to restore
+	RestoreReportFile string
+	// ids or names of deleted versions of the packages which are to restore
+	RestoreVersions []string
+	// indicator whether to restore the deleted packages themselves
+	RestorePackage bool
+	// Path of the yaml or json configuration file whose values are overridden by environment variables
+	ConfigFile string
+	// configurations of the packages of the configuration file, each with its own rules. If there are any, they are handled instead of the package names and pattern
+	Packages []Config
+	// Path of the plan file: written with the candidates at mode delete and read at mode apply
+	PlanFile string
+	// Overall deadline of a run in seconds. If it elapses, no further rest calls are executed. Not positive if there is no deadline
+	RunTimeout int
 }
 
 /*
@@ -73,6 +214,10 @@ Reads the configuration from environment variables:
   - USER
   - PACKAGE_TYPE
   - PACKAGE_NAME
+  - PACKAGE_NAME_PATTERN
+  - REPOSITORY
+  - CURRENT_REPOSITORY
+  - GITHUB_REPOSITORY
   - VERSION_NAME_TO_DELETE
   - DELETE_SNAPSHOTS
   - NUMBER_MAJOR_TO_KEEP
@@ -81,30 +226,137 @@ Reads the configuration from environment variables:
   - GITHUB_TOKEN
   - DEBUG_LOGS
   - REST_TIMEOUT
+  - PAGE_SIZE
+  - MAX_RETRIES
+  - RETRY_MAX_WAIT
+  - MAX_CONCURRENT_DELETIONS
+  - DELETION_DELAY
+  - REPORT_FILE
+  - GITHUB_STEP_SUMMARY
+  - GITHUB_OUTPUT
+  - DELETE_UNTAGGED
+  - TAG_PATTERN_TO_DELETE
+  - PROTECTED_TAGS
+  - MAX_AGE_SNAPSHOTS
+  - MAX_AGE_RELEASES
+  - MIN_AGE
+  - NUMBER_NEWEST_RELEASES_TO_KEEP
+  - VERSION_PATTERNS_TO_DELETE
+  - VERSION_PATTERNS_TO_KEEP
+  - EMPTY_PACKAGE_POLICY
+  - MIN_VERSIONS_TO_KEEP
+  - MAX_VERSIONS_TO_DELETE
+  - FORCE
+  - MODE
+  - RESTORE_REPORT_FILE
+  - RESTORE_VERSIONS
+  - RESTORE_PACKAGE
+  - CONFIG_FILE
+  - PLAN_FILE
+  - RUN_TIMEOUT
+  - APP_ID
+  - APP_INSTALLATION_ID
+  - APP_PRIVATE_KEY
+
+If there is a configuration file, its values are used as defaults of the environment variables
 */
 func ReadConfiguration() (*Config, error) {
 	var config Config
-	config.GitHubRestUrl = getTrimEnvOrDefault(ENV_NAME_GITHUB_REST_API_URL, gitHubUrl)
-	config.Organization = getTrimEnv(ENV_NAME_ORGANIZATION)
-	config.User = getTrimEnv(ENV_NAME_USER)
-	config.PackageType = mapToPackageType(getTrimEnv(ENV_NAME_PACKAGE_TYPE))
-	config.PackageName = getTrimEnv(ENV_NAME_PACKAGE_NAME)
-	config.VersionNameToDelete = getTrimEnv(ENV_NAME_VERSION_NAME_TO_DELETE)
-	config.DeleteSnapshots = getBoolEnv(ENV_NAME_DELETE_SNAPSHOTS)
-	config.NumberOfMajorVersionsToKeep = getIntEnv(ENV_NAME_NUMBER_MAJOR_TO_KEEP)
-	config.NumberOfMinorVersionsToKeep = getIntEnv(ENV_NAME_NUMBER_MINOR_TO_KEEP)
-	config.NumberOfPatchVersionsToKeep = getIntEnv(ENV_NAME_NUMBER_PATCH_TO_KEEP)
+	config.ConfigFile = getTrimEnv(ENV_NAME_CONFIG_FILE)
+	file, errs, err := readConfigFile(config.ConfigFile)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	config.GitHubRestUrl = getTrimEnvOrDefault(ENV_NAME_GITHUB_REST_API_URL, valueOrDefault(file.GitHubRestUrl, gitHubUrl))
+	config.Organization = getTrimEnvOrDefault(ENV_NAME_ORGANIZATION, valueOrDefault(file.Organization, ""))
+	config.User = getTrimEnvOrDefault(ENV_NAME_USER, valueOrDefault(file.User, ""))
+	config.PackageType = getMappedEnvDefault(ENV_NAME_PACKAGE_TYPE, valueOrDefault(file.PackageType, ""), mapToPackageType, expectedPackageType, &errs)
+	config.PackageNames = getListEnvDefault(ENV_NAME_PACKAGE_NAME, []string{})
+	if len(config.PackageNames) > 0 {
+		config.PackageName = config.PackageNames[0]
+	}
+	config.PackageNamePattern = getTrimEnv(ENV_NAME_PACKAGE_NAME_PATTERN)
+	config.Repository = getTrimEnvOrDefault(ENV_NAME_REPOSITORY, valueOrDefault(file.Repository, ""))
+	if getBoolEnvDefault(ENV_NAME_CURRENT_REPOSITORY, valueOrDefault(file.CurrentRepository, false), &errs) {
+		config.Repository = readCurrentRepository(config.Repository, &errs)
+	}
 	config.GithubToken = getTrimEnv(ENV_NAME_GITHUB_TOKEN)
-	config.DryRun = getBoolEnvDefault(ENV_NAME_DRY_RUN, true)
-	config.Debug = getBoolEnvDefault(ENV_NAME_DEBUG, false)
-	config.Timeout = getIntEnvDefault(ENV_NAME_TIMEOUT, 3)
+	config.AppId = getTrimEnv(ENV_NAME_APP_ID)
+	config.AppInstallationId = getIntEnvDefault(ENV_NAME_APP_INSTALLATION_ID, 0, &errs)
+	config.AppPrivateKey = readAppPrivateKey(getTrimEnv(ENV_NAME_APP_PRIVATE_KEY), &errs)
+	config.DryRun = getBoolEnvDefault(ENV_NAME_DRY_RUN, valueOrDefault(file.DryRun, true), &errs)
+	config.Debug = getBoolEnvDefault(ENV_NAME_DEBUG, valueOrDefault(file.Debug, false), &errs)
+	config.Timeout = getIntEnvDefault(ENV_NAME_TIMEOUT, valueOrDefault(file.Timeout, 3), &errs)
+	config.PageSize = getIntEnvDefault(ENV_NAME_PAGE_SIZE, valueOrDefault(file.PageSize, maxPageSize), &errs)
+	config.MaxRetries = getIntEnvDefaultWithMinimum(ENV_NAME_MAX_RETRIES, valueOrDefault(file.MaxRetries, defaultMaxRetries), 0, &errs)
+	config.RetryMaxWait = getIntEnvDefault(ENV_NAME_RETRY_MAX_WAIT, valueOrDefault(file.RetryMaxWait, defaultRetryMaxWait), &errs)
+	config.MaxConcurrentDeletions = getIntEnvDefault(ENV_NAME_MAX_CONCURRENT_DELETIONS, valueOrDefault(file.MaxConcurrentDeletions, defaultMaxConcurrentDeletions), &errs)
+	config.DeletionDelay = getIntEnvDefaultWithMinimum(ENV_NAME_DELETION_DELAY, valueOrDefault(file.DeletionDelay, 0), 0, &errs)
+	config.ReportFile = getTrimEnvOrDefault(ENV_NAME_REPORT_FILE, valueOrDefault(file.ReportFile, ""))
+	config.StepSummaryFile = getTrimEnv(ENV_NAME_STEP_SUMMARY)
+	config.OutputFile = getTrimEnv(ENV_NAME_OUTPUT)
+	config.RunTimeout = getIntEnvDefault(ENV_NAME_RUN_TIMEOUT, valueOrDefault(file.RunTimeout, -1), &errs)
+	config.MaxVersionsToDelete = getIntEnvDefault(ENV_NAME_MAX_VERSIONS_TO_DELETE, valueOrDefault(file.MaxVersionsToDelete, -1), &errs)
+	config.Force = getBoolEnvDefault(ENV_NAME_FORCE, valueOrDefault(file.Force, false), &errs)
+	config.Mode = getMappedEnvDefault(ENV_NAME_MODE, MODE_DELETE, mapToMode, expectedMode, &errs)
+	config.RestoreReportFile = getTrimEnv(ENV_NAME_RESTORE_REPORT_FILE)
+	config.RestoreVersions = getListEnvDefault(ENV_NAME_RESTORE_VERSIONS, []string{})
+	config.RestorePackage = getBoolEnvDefault(ENV_NAME_RESTORE_PACKAGE, false, &errs)
+	config.PlanFile = getTrimEnv(ENV_NAME_PLAN_FILE)
+	readRules(&config, &file.Rules, &errs)
+
+	if len(file.Packages) > 0 && len(config.PackageNames) == 0 && config.PackageNamePattern == "" {
+		config.Packages = createPackageConfigs(&config, file, &errs)
+		for _, p := range config.Packages {
+			config.PackageNames = append(config.PackageNames, p.PackageNames...)
+		}
+	}
 
 	printConfig(&config)
 
-	if isValid(&config) {
-		return &config, nil
+	validate(&config, &errs)
+	if len(errs) > 0 {
+		for _, e := range errs {
+			logger.Error(e.Error())
+		}
+		return nil, errs
+	}
+	return &config, nil
+}
+
+// Reads the rules of a configuration from environment variables. The rules of the configuration file are used as defaults
+func readRules(config *Config, rules *fileRules, errs *ValidationErrors) {
+	config.VersionNameToDelete = getTrimEnvOrDefault(ENV_NAME_VERSION_NAME_TO_DELETE, valueOrDefault(rules.VersionNameToDelete, ""))
+	config.DeleteSnapshots = getBoolEnvDefault(ENV_NAME_DELETE_SNAPSHOTS, valueOrDefault(rules.DeleteSnapshots, false), errs)
+	config.NumberOfMajorVersionsToKeep = getIntEnvDefault(ENV_NAME_NUMBER_MAJOR_TO_KEEP, valueOrDefault(rules.NumberOfMajorVersionsToKeep, -1), errs)
+	config.NumberOfMinorVersionsToKeep = getIntEnvDefault(ENV_NAME_NUMBER_MINOR_TO_KEEP, valueOrDefault(rules.NumberOfMinorVersionsToKeep, -1), errs)
+	config.NumberOfPatchVersionsToKeep = getIntEnvDefault(ENV_NAME_NUMBER_PATCH_TO_KEEP, valueOrDefault(rules.NumberOfPatchVersionsToKeep, -1), errs)
+	config.DeleteUntagged = getBoolEnvDefault(ENV_NAME_DELETE_UNTAGGED, valueOrDefault(rules.DeleteUntagged, false), errs)
+	config.TagPatternToDelete = getTrimEnvOrDefault(ENV_NAME_TAG_PATTERN_TO_DELETE, valueOrDefault(rules.TagPatternToDelete, ""))
+	config.ProtectedTags = getListEnvDefault(ENV_NAME_PROTECTED_TAGS, valueOrDefault(rules.ProtectedTags, []string{latestTag}))
+	config.MaxAgeOfSnapshots = getIntEnvDefault(ENV_NAME_MAX_AGE_SNAPSHOTS, valueOrDefault(rules.MaxAgeOfSnapshots, -1), errs)
+	config.MaxAgeOfReleases = getIntEnvDefault(ENV_NAME_MAX_AGE_RELEASES, valueOrDefault(rules.MaxAgeOfReleases, -1), errs)
+	config.MinAge = getIntEnvDefault(ENV_NAME_MIN_AGE, valueOrDefault(rules.MinAge, -1), errs)
+	config.NumberOfNewestReleasesToKeep = getIntEnvDefault(ENV_NAME_NUMBER_NEWEST_TO_KEEP, valueOrDefault(rules.NumberOfNewestReleasesToKeep, -1), errs)
+	config.VersionPatternsToDelete = getListEnvDefault(ENV_NAME_VERSION_PATTERNS_TO_DELETE, valueOrDefault(rules.VersionPatternsToDelete, []string{}))
+	config.VersionPatternsToKeep = getListEnvDefault(ENV_NAME_VERSION_PATTERNS_TO_KEEP, valueOrDefault(rules.VersionPatternsToKeep, []string{}))
+	config.EmptyPackagePolicy = getMappedEnvDefault(ENV_NAME_EMPTY_PACKAGE_POLICY, valueOrDefault(rules.EmptyPackagePolicy, EMPTY_PACKAGE_KEEP_LATEST), mapToEmptyPackagePolicy, expectedEmptyPolicy, errs)
+	config.MinVersionsToKeep = getIntEnvDefault(ENV_NAME_MIN_VERSIONS_TO_KEEP, valueOrDefault(rules.MinVersionsToKeep, -1), errs)
+}
+
+// Reads the repository the workflow runs in. It must not be given together with another repository
+func readCurrentRepository(repository string, errs *ValidationErrors) string {
+	if repository != "" {
+		errs.add(ENV_NAME_CURRENT_REPOSITORY, "true", "not to be set together with "+ENV_NAME_REPOSITORY)
+		return repository
 	}
-	return nil, errors.New("invalid configuration")
+	res := getTrimEnv(ENV_NAME_GITHUB_REPOSITORY)
+	if res == "" {
+		errs.add(ENV_NAME_GITHUB_REPOSITORY, "", "the repository the workflow runs in if "+ENV_NAME_CURRENT_REPOSITORY+" is set")
+	}
+	return res
 }
 
 // determines an environment variable and return it as trimmed string. If empty the default value will be returned
@@ -125,41 +377,75 @@ func getTrimEnv(envName string) string {
 	return strings.TrimSpace(os.Getenv(ENV_GITHUB_PREFIX + envName))
 }
 
-// determines an environment variable and return it as bool
-func getBoolEnv(envName string) bool {
-	return strings.EqualFold("true", getTrimEnv(envName))
-}
-
-// determines an environment variable and return it as bool if present, other wise the given default value
-func getBoolEnvDefault(envName string, defaultValue bool) bool {
+// determines an environment variable and return it as bool if present, other wise the given default value. Values other than true or false are collected as problem
+func getBoolEnvDefault(envName string, defaultValue bool, errs *ValidationErrors) bool {
 	value := getTrimEnv(envName)
-	if value == "" {
+	switch {
+	case value == "":
+		return defaultValue
+	case strings.EqualFold("true", value):
+		return true
+	case strings.EqualFold("false", value):
+		return false
+	default:
+		errs.add(envName, value, expectedBool)
 		return defaultValue
 	}
-	return strings.EqualFold("true", value)
 }
 
-// determines an environment variable and return it as int.
-func getIntEnv(envName string) int {
-	return getIntEnvDefault(envName, -1)
+// determines an environment variable and return it as positive int if present, other wise the given default value
+func getIntEnvDefault(envName string, defaultValue int, errs *ValidationErrors) int {
+	return getIntEnvDefaultWithMinimum(envName, defaultValue, 1, errs)
 }
 
-// determines an environment variable and return it as int if present, other wise the given default value
-func getIntEnvDefault(envName string, defaultValue int) int {
+// determines an environment variable and return it as int of at least a minimum if present, other wise the given default value.
+// Values which are no integer or less than the minimum are collected as problem
+func getIntEnvDefaultWithMinimum(envName string, defaultValue int, minimum int, errs *ValidationErrors) int {
 	envValue := getTrimEnv(envName)
-	if envValue != "" {
-		res, err := strconv.Atoi(os.Getenv(envName))
-		if err != nil {
-			logger.Error(err)
-			return defaultValue
+	if envValue == "" {
+		return defaultValue
+	}
+	res, err := strconv.Atoi(envValue)
+	if err != nil || res < minimum {
+		expected := expectedPositive
+		if minimum == 0 {
+			expected = expectedNotNegative
 		}
-		if res <= 0 {
-			logger.Error("Only positiv values are allowed but found ", res, " for environment variable ", envName)
-			return defaultValue
+		errs.add(envName, envValue, expected)
+		return defaultValue
+	}
+	return res
+}
+
+// determines an environment variable, or if not present the given default value, mapped by a mapper. Values which are mapped to unknown are collected as problem
+func getMappedEnvDefault(envName string, defaultValue string, mapper func(string) string, expected string, errs *ValidationErrors) string {
+	value := getTrimEnvOrDefault(envName, defaultValue)
+	res := mapper(value)
+	if res == UNKNOWN {
+		errs.add(envName, value, expected)
+	}
+	return res
+}
+
+// determines an environment variable and return it as list of trimmed strings. The elements are separated by comma or line breaks.
+// If there is none, the given default value will be returned
+func getListEnvDefault(envName string, defaultValue []string) []string {
+	envValue := getTrimEnv(envName)
+	if envValue == "" {
+		return defaultValue
+	}
+	var result []string
+	for _, element := range strings.FieldsFunc(envValue, isListSeparator) {
+		if trimmed := strings.TrimSpace(element); trimmed != "" {
+			result = append(result, trimmed)
 		}
-		return res
 	}
-	return defaultValue
+	return result
+}
+
+// checks whether a rune separates elements of a list
+func isListSeparator(r rune) bool {
+	return r == ',' || r == '\n' || r == '\r'
 }
 
 // maps a given string to a package type
@@ -167,35 +453,53 @@ func mapToPackageType(toMap string) string {
 	switch strings.ToLower(toMap) {
 	case MAVEN:
 		return MAVEN
+	case NPM:
+		return NPM
+	case CONTAINER:
+		return CONTAINER
+	case DOCKER:
+		return DOCKER
 	default:
 		return UNKNOWN
 	}
 }
 
-// Checks whether a given configuration is valid or not
-func isValid(config *Config) bool {
-	if (config.Organization != "" && config.User != "") || (config.Organization == "" && config.User == "") {
-		logger.Error("Fill exactly one: Either user or organization")
-		return false
-	}
-	if config.PackageType == UNKNOWN {
-		logger.Error("The packagetype is unknown")
-		return false
-	}
-	if config.PackageName == "" {
-		logger.Error("Missing package name")
-		return false
+// maps a given string to an empty package policy
+func mapToEmptyPackagePolicy(toMap string) string {
+	switch strings.ToLower(toMap) {
+	case EMPTY_PACKAGE_DELETE_PACKAGE:
+		return EMPTY_PACKAGE_DELETE_PACKAGE
+	case EMPTY_PACKAGE_KEEP_LATEST:
+		return EMPTY_PACKAGE_KEEP_LATEST
+	case EMPTY_PACKAGE_FAIL:
+		return EMPTY_PACKAGE_FAIL
+	default:
+		return UNKNOWN
 	}
-	if config.GithubToken == "" {
-		logger.Error("Missing GitHub token")
-		return false
+}
+
+// maps a given string to a mode
+func mapToMode(toMap string) string {
+	switch strings.ToLower(toMap) {
+	case MODE_DELETE:
+		return MODE_DELETE
+	case MODE_RESTORE:
+		return MODE_RESTORE
+	case MODE_LIST:
+		return MODE_LIST
+	case MODE_APPLY:
+		return MODE_APPLY
+	default:
+		return UNKNOWN
 	}
-	if config.VersionNameToDelete == "" && !config.DeleteSnapshots &&
-		config.NumberOfMajorVersionsToKeep <= 0 && config.NumberOfMinorVersionsToKeep <= 0 && config.NumberOfPatchVersionsToKeep <= 0 {
-		logger.Error("Nothing configured to delete: set a conrete version name, snapshot deletion or major, minor or patch to keep")
-		return false
+}
+
+// returns the name or, if there is none, the name pattern of a package configuration
+func getPackageDescription(config *Config) string {
+	if config.PackageName != "" {
+		return config.PackageName
 	}
-	return true
+	return config.PackageNamePattern
 }
 
 // prints a given configuration to the standard output
@@ -205,7 +509,11 @@ func printConfig(config *Config) {
 	logger.Information("  Organization:        ", config.Organization)
 	logger.Information("  User:                ", config.User)
 	logger.Information("  PackageType:         ", config.PackageType)
-	logger.Information("  PackageName:         ", config.PackageName)
+	logger.Information("  PackageNames:        ", strings.Join(config.PackageNames, ", "))
+	logger.Information("  PackageNamePattern:  ", config.PackageNamePattern)
+	if config.Repository != "" {
+		logger.Information("  Repository:          ", config.Repository)
+	}
 	logger.Information("  VersionNameToDelete: ", config.VersionNameToDelete)
 	logger.Information("  DeleteSnapshots:     ", config.DeleteSnapshots)
 	printPositiv("  MajorVersionsToKeep: ", config.NumberOfMajorVersionsToKeep)
@@ -216,9 +524,65 @@ func printConfig(config *Config) {
 	} else {
 		logger.Information("  GithubToken:")
 	}
+	if config.AppId != "" {
+		logger.Information("  AppId:               ", config.AppId)
+		logger.Information("  AppInstallationId:   ", config.AppInstallationId)
+	}
 	logger.Information("  DryRun:              ", config.DryRun)
 	logger.Information("  DebugLog:            ", config.Debug)
 	logger.Information("  RestTimeout:         ", config.Timeout)
+	logger.Information("  PageSize:            ", config.PageSize)
+	logger.Information("  MaxRetries:          ", config.MaxRetries)
+	logger.Information("  RetryMaxWait:        ", config.RetryMaxWait)
+	logger.Information("  MaxConcurrentDeletions: ", config.MaxConcurrentDeletions)
+	logger.Information("  DeletionDelay:       ", config.DeletionDelay)
+	logger.Information("  ReportFile:          ", config.ReportFile)
+	logger.Information("  StepSummaryFile:     ", config.StepSummaryFile)
+	logger.Information("  OutputFile:          ", config.OutputFile)
+	logger.Information("  DeleteUntagged:      ", config.DeleteUntagged)
+	logger.Information("  TagPatternToDelete:  ", config.TagPatternToDelete)
+	logger.Information("  ProtectedTags:       ", strings.Join(config.ProtectedTags, ", "))
+	printPositiv("  MaxAgeOfSnapshots:   ", config.MaxAgeOfSnapshots)
+	printPositiv("  MaxAgeOfReleases:    ", config.MaxAgeOfReleases)
+	printPositiv("  MinAge:              ", config.MinAge)
+	printPositiv("  NewestReleasesToKeep:", config.NumberOfNewestReleasesToKeep)
+	logger.Information("  VersionPatternsToDelete: ", strings.Join(config.VersionPatternsToDelete, ", "))
+	logger.Information("  VersionPatternsToKeep:   ", strings.Join(config.VersionPatternsToKeep, ", "))
+	logger.Information("  EmptyPackagePolicy:  ", config.EmptyPackagePolicy)
+	printPositiv("  MinVersionsToKeep:   ", config.MinVersionsToKeep)
+	printPositiv("  MaxVersionsToDelete: ", config.MaxVersionsToDelete)
+	printPositiv("  RunTimeout:          ", config.RunTimeout)
+	logger.Information("  Force:               ", config.Force)
+	logger.Information("  Mode:                ", config.Mode)
+	logger.Information("  RestoreReportFile:   ", config.RestoreReportFile)
+	logger.Information("  RestoreVersions:     ", strings.Join(config.RestoreVersions, ", "))
+	logger.Information("  RestorePackage:      ", config.RestorePackage)
+	logger.Information("  ConfigFile:          ", config.ConfigFile)
+	logger.Information("  PlanFile:            ", config.PlanFile)
+	for i := range config.Packages {
+		printPackageConfig(&config.Packages[i])
+	}
+}
+
+// prints the rules of a package configuration of the configuration file to the standard output
+func printPackageConfig(config *Config) {
+	logger.Information("  Package:             ", getPackageDescription(config))
+	logger.Information("    VersionNameToDelete: ", config.VersionNameToDelete)
+	logger.Information("    DeleteSnapshots:     ", config.DeleteSnapshots)
+	printPositiv("    MajorVersionsToKeep: ", config.NumberOfMajorVersionsToKeep)
+	printPositiv("    MinorVersionsToKeep: ", config.NumberOfMinorVersionsToKeep)
+	printPositiv("    PatchVersionsToKeep: ", config.NumberOfPatchVersionsToKeep)
+	logger.Information("    DeleteUntagged:      ", config.DeleteUntagged)
+	logger.Information("    TagPatternToDelete:  ", config.TagPatternToDelete)
+	logger.Information("    ProtectedTags:       ", strings.Join(config.ProtectedTags, ", "))
+	printPositiv("    MaxAgeOfSnapshots:   ", config.MaxAgeOfSnapshots)
+	printPositiv("    MaxAgeOfReleases:    ", config.MaxAgeOfReleases)
+	printPositiv("    MinAge:              ", config.MinAge)
+	printPositiv("    NewestReleasesToKeep:", config.NumberOfNewestReleasesToKeep)
+	logger.Information("    VersionPatternsToDelete: ", strings.Join(config.VersionPatternsToDelete, ", "))
+	logger.Information("    VersionPatternsToKeep:   ", strings.Join(config.VersionPatternsToKeep, ", "))
+	logger.Information("    EmptyPackagePolicy:  ", config.EmptyPackagePolicy)
+	printPositiv("    MinVersionsToKeep:   ", config.MinVersionsToKeep)
 }
 
 func printPositiv(text string, value int) {
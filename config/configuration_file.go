@@ -0,0 +1,188 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// rules which can be set at the configuration file globally or per package. Keys are the lower case names of the environment variables
+type fileRules struct {
+	VersionNameToDelete          *string   `yaml:"version_name_to_delete"`
+	DeleteSnapshots              *bool     `yaml:"delete_snapshots"`
+	NumberOfMajorVersionsToKeep  *int      `yaml:"number_major_to_keep"`
+	NumberOfMinorVersionsToKeep  *int      `yaml:"number_minor_to_keep"`
+	NumberOfPatchVersionsToKeep  *int      `yaml:"number_patch_to_keep"`
+	DeleteUntagged               *bool     `yaml:"delete_untagged"`
+	TagPatternToDelete           *string   `yaml:"tag_pattern_to_delete"`
+	ProtectedTags                *[]string `yaml:"protected_tags"`
+	MaxAgeOfSnapshots            *int      `yaml:"max_age_snapshots"`
+	MaxAgeOfReleases             *int      `yaml:"max_age_releases"`
+	MinAge                       *int      `yaml:"min_age"`
+	NumberOfNewestReleasesToKeep *int      `yaml:"number_newest_releases_to_keep"`
+	VersionPatternsToDelete      *[]string `yaml:"version_patterns_to_delete"`
+	VersionPatternsToKeep        *[]string `yaml:"version_patterns_to_keep"`
+	EmptyPackagePolicy           *string   `yaml:"empty_package_policy"`
+	MinVersionsToKeep            *int      `yaml:"min_versions_to_keep"`
+}
+
+// a package, or the packages matching a pattern, with its own rules at the configuration file
+type filePackage struct {
+	Name        string    `yaml:"name"`
+	NamePattern string    `yaml:"name_pattern"`
+	Rules       fileRules `yaml:",inline"`
+}
+
+// content of a configuration file. Keys are the lower case names of the environment variables
+type fileConfig struct {
+	GitHubRestUrl          *string       `yaml:"github_rest_api_url"`
+	Organization           *string       `yaml:"github_organization"`
+	User                   *string       `yaml:"github_user"`
+	PackageType            *string       `yaml:"package_type"`
+	Repository             *string       `yaml:"repository"`
+	CurrentRepository      *bool         `yaml:"current_repository"`
+	DryRun                 *bool         `yaml:"dry_run"`
+	Debug                  *bool         `yaml:"debug_logs"`
+	Timeout                *int          `yaml:"rest_timeout"`
+	PageSize               *int          `yaml:"page_size"`
+	MaxRetries             *int          `yaml:"max_retries"`
+	RetryMaxWait           *int          `yaml:"retry_max_wait"`
+	MaxConcurrentDeletions *int          `yaml:"max_concurrent_deletions"`
+	DeletionDelay          *int          `yaml:"deletion_delay"`
+	ReportFile             *string       `yaml:"report_file"`
+	MaxVersionsToDelete    *int          `yaml:"max_versions_to_delete"`
+	Force                  *bool         `yaml:"force"`
+	RunTimeout             *int          `yaml:"run_timeout"`
+	Rules                  fileRules     `yaml:",inline"`
+	Packages               []filePackage `yaml:"packages"`
+}
+
+// Reads a configuration file in yaml or json format. Without a path an empty configuration is returned.
+// Unknown keys fail the reading. Values which are not valid are returned as problems with the offending key
+// together with the read configuration, so that they can be reported together with the ones of the environment variables
+func readConfigFile(configFile string) (*fileConfig, ValidationErrors, error) {
+	var file fileConfig
+	if configFile == "" {
+		return &file, nil, nil
+	}
+
+	content, err := os.ReadFile(configFile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err = decoder.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, nil, fmt.Errorf("config file %s: %w", configFile, err)
+	}
+
+	return &file, validateFileConfig(&file), nil
+}
+
+// Validates the values of a configuration file. All problems are collected with the offending keys
+func validateFileConfig(file *fileConfig) ValidationErrors {
+	var errs ValidationErrors
+	if file.PackageType != nil && mapToPackageType(*file.PackageType) == UNKNOWN {
+		errs.add("package_type", *file.PackageType, expectedPackageType)
+	}
+	errs.addIfNotPositive("rest_timeout", file.Timeout)
+	errs.addIfNotPositive("page_size", file.PageSize)
+	if file.PageSize != nil && *file.PageSize > maxPageSize {
+		errs.add("page_size", strconv.Itoa(*file.PageSize), fmt.Sprintf("an integer between 1 and %d", maxPageSize))
+	}
+	if file.MaxRetries != nil && (*file.MaxRetries < 0 || *file.MaxRetries > maxRetries) {
+		errs.add("max_retries", strconv.Itoa(*file.MaxRetries), fmt.Sprintf("an integer between 0 and %d", maxRetries))
+	}
+	errs.addIfNotPositive("retry_max_wait", file.RetryMaxWait)
+	errs.addIfNotPositive("max_concurrent_deletions", file.MaxConcurrentDeletions)
+	if file.DeletionDelay != nil && *file.DeletionDelay < 0 {
+		errs.add("deletion_delay", strconv.Itoa(*file.DeletionDelay), expectedNotNegative)
+	}
+	errs.addIfNotPositive("max_versions_to_delete", file.MaxVersionsToDelete)
+	errs.addIfNotPositive("run_timeout", file.RunTimeout)
+	validateFileRules("", &file.Rules, &errs)
+
+	for i, p := range file.Packages {
+		prefix := fmt.Sprintf("packages[%d].", i)
+		switch {
+		case p.Name == "" && p.NamePattern == "":
+			errs.add(prefix+"name", "", "either name or name_pattern")
+		case p.Name != "" && p.NamePattern != "":
+			errs.add(prefix+"name_pattern", p.NamePattern, "no pattern if there is a name")
+		}
+		errs.addIfPatternsInvalid(prefix+"name_pattern", nonEmpty(p.NamePattern))
+		validateFileRules(prefix, &p.Rules, &errs)
+	}
+	return errs
+}
+
+// Validates the rules of a configuration file whose keys are prefixed by a given one
+func validateFileRules(prefix string, rules *fileRules, errs *ValidationErrors) {
+	errs.addIfNotPositive(prefix+"number_major_to_keep", rules.NumberOfMajorVersionsToKeep)
+	errs.addIfNotPositive(prefix+"number_minor_to_keep", rules.NumberOfMinorVersionsToKeep)
+	errs.addIfNotPositive(prefix+"number_patch_to_keep", rules.NumberOfPatchVersionsToKeep)
+	errs.addIfNotPositive(prefix+"max_age_snapshots", rules.MaxAgeOfSnapshots)
+	errs.addIfNotPositive(prefix+"max_age_releases", rules.MaxAgeOfReleases)
+	errs.addIfNotPositive(prefix+"min_age", rules.MinAge)
+	errs.addIfNotPositive(prefix+"number_newest_releases_to_keep", rules.NumberOfNewestReleasesToKeep)
+	errs.addIfNotPositive(prefix+"min_versions_to_keep", rules.MinVersionsToKeep)
+	if rules.TagPatternToDelete != nil {
+		errs.addIfRegexInvalid(prefix+"tag_pattern_to_delete", *rules.TagPatternToDelete)
+	}
+	if rules.VersionPatternsToDelete != nil {
+		errs.addIfPatternsInvalid(prefix+"version_patterns_to_delete", *rules.VersionPatternsToDelete)
+	}
+	if rules.VersionPatternsToKeep != nil {
+		errs.addIfPatternsInvalid(prefix+"version_patterns_to_keep", *rules.VersionPatternsToKeep)
+	}
+	if rules.EmptyPackagePolicy != nil && mapToEmptyPackagePolicy(*rules.EmptyPackagePolicy) == UNKNOWN {
+		errs.add(prefix+"empty_package_policy", *rules.EmptyPackagePolicy, expectedEmptyPolicy)
+	}
+}
+
+// Merges the rules of a package with the global ones of the configuration file: values of the package take precedence
+func mergeFileRules(globalRules *fileRules, packageRules *fileRules) *fileRules {
+	res := *globalRules
+	resValue := reflect.ValueOf(&res).Elem()
+	packageValue := reflect.ValueOf(packageRules).Elem()
+	for i := range packageValue.NumField() {
+		if !packageValue.Field(i).IsNil() {
+			resValue.Field(i).Set(packageValue.Field(i))
+		}
+	}
+	return &res
+}
+
+// Creates the configurations of the packages of the configuration file. Each one is a copy of the global configuration
+// whose rules are read from the environment with the merged rules of the file as defaults
+func createPackageConfigs(config *Config, file *fileConfig, errs *ValidationErrors) []Config {
+	var res []Config
+	for _, p := range file.Packages {
+		packageConfig := *config
+		packageConfig.Packages = nil
+		packageConfig.PackageName = p.Name
+		packageConfig.PackageNames = nil
+		if p.Name != "" {
+			packageConfig.PackageNames = []string{p.Name}
+		}
+		packageConfig.PackageNamePattern = p.NamePattern
+		readRules(&packageConfig, mergeFileRules(&file.Rules, &p.Rules), errs)
+		res = append(res, packageConfig)
+	}
+	return res
+}
+
+// returns the value of a pointer or, if it is nil, the default value
+func valueOrDefault[T any](value *T, defaultValue T) T {
+	if value == nil {
+		return defaultValue
+	}
+	return *value
+}
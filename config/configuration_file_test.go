@@ -0,0 +1,207 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ma-vin/testutil-go"
+)
+
+const yamlConfigFile = `github_user: Ma-Vin
+package_type: maven
+dry_run: false
+page_size: 50
+number_major_to_keep: 3
+packages:
+  - name: packages-action-app
+    delete_snapshots: true
+  - name: packages-action-lib
+    number_major_to_keep: 1
+    protected_tags: [stable]
+  - name_pattern: ^tool-.*
+    version_patterns_to_delete: [.*-rc.*]
+    empty_package_policy: delete-package
+`
+
+const jsonConfigFile = `{
+  "github_organization": "Ma-Vin-Org",
+  "package_type": "npm",
+  "delete_snapshots": true,
+  "packages": [
+    {"name": "packages-action-app", "number_minor_to_keep": 2}
+  ]
+}`
+
+func writeConfigFile(content string, fileName string, t *testing.T) string {
+	path := filepath.Join(t.TempDir(), fileName)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestReadConfigurationConfigFileYaml(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_CONFIG_FILE, writeConfigFile(yamlConfigFile, "config.yaml", t))
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(conf, t, "conf")
+	testutil.AssertEquals("Ma-Vin", conf.User, t, "user")
+	testutil.AssertEquals(MAVEN, conf.PackageType, t, "package type")
+	testutil.AssertFalse(conf.DryRun, t, "dry run")
+	testutil.AssertEquals(50, conf.PageSize, t, "page size")
+	testutil.AssertEquals(3, conf.NumberOfMajorVersionsToKeep, t, "number of major versions")
+	testutil.AssertEquals("packages-action-app, packages-action-lib", strings.Join(conf.PackageNames, ", "), t, "package names")
+	testutil.AssertEquals(3, len(conf.Packages), t, "number of packages")
+
+	testutil.AssertEquals("packages-action-app", conf.Packages[0].PackageName, t, "packages[0] name")
+	testutil.AssertTrue(conf.Packages[0].DeleteSnapshots, t, "packages[0] delete snapshots")
+	testutil.AssertEquals(3, conf.Packages[0].NumberOfMajorVersionsToKeep, t, "packages[0] number of major versions")
+	testutil.AssertEquals(50, conf.Packages[0].PageSize, t, "packages[0] page size")
+
+	testutil.AssertEquals("packages-action-lib", conf.Packages[1].PackageName, t, "packages[1] name")
+	testutil.AssertFalse(conf.Packages[1].DeleteSnapshots, t, "packages[1] delete snapshots")
+	testutil.AssertEquals(1, conf.Packages[1].NumberOfMajorVersionsToKeep, t, "packages[1] number of major versions")
+	testutil.AssertEquals("stable", strings.Join(conf.Packages[1].ProtectedTags, ", "), t, "packages[1] protected tags")
+
+	testutil.AssertEquals("", conf.Packages[2].PackageName, t, "packages[2] name")
+	testutil.AssertEquals("^tool-.*", conf.Packages[2].PackageNamePattern, t, "packages[2] name pattern")
+	testutil.AssertEquals(".*-rc.*", strings.Join(conf.Packages[2].VersionPatternsToDelete, ", "), t, "packages[2] version patterns to delete")
+	testutil.AssertEquals(EMPTY_PACKAGE_DELETE_PACKAGE, conf.Packages[2].EmptyPackagePolicy, t, "packages[2] empty package policy")
+	testutil.AssertEquals(latestTag, strings.Join(conf.Packages[2].ProtectedTags, ", "), t, "packages[2] protected tags")
+}
+
+func TestReadConfigurationConfigFileJson(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_CONFIG_FILE, writeConfigFile(jsonConfigFile, "config.json", t))
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(conf, t, "conf")
+	testutil.AssertEquals("Ma-Vin-Org", conf.Organization, t, "organization")
+	testutil.AssertEquals(NPM, conf.PackageType, t, "package type")
+	testutil.AssertTrue(conf.DryRun, t, "dry run")
+	testutil.AssertEquals(1, len(conf.Packages), t, "number of packages")
+	testutil.AssertTrue(conf.Packages[0].DeleteSnapshots, t, "packages[0] delete snapshots")
+	testutil.AssertEquals(2, conf.Packages[0].NumberOfMinorVersionsToKeep, t, "packages[0] number of minor versions")
+}
+
+func TestReadConfigurationConfigFileEnvOverride(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_CONFIG_FILE, writeConfigFile(yamlConfigFile, "config.yaml", t))
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	os.Setenv(ENV_NAME_PAGE_SIZE, "20")
+	os.Setenv(ENV_NAME_NUMBER_MAJOR_TO_KEEP, "5")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(conf, t, "conf")
+	testutil.AssertEquals(20, conf.PageSize, t, "page size")
+	testutil.AssertEquals(5, conf.NumberOfMajorVersionsToKeep, t, "number of major versions")
+	testutil.AssertEquals(5, conf.Packages[1].NumberOfMajorVersionsToKeep, t, "packages[1] number of major versions")
+}
+
+func TestReadConfigurationConfigFileEnvPackageName(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_CONFIG_FILE, writeConfigFile(yamlConfigFile, "config.yaml", t))
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	os.Setenv(ENV_NAME_PACKAGE_NAME, "other-package")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(conf, t, "conf")
+	testutil.AssertEquals("other-package", strings.Join(conf.PackageNames, ", "), t, "package names")
+	testutil.AssertEquals(0, len(conf.Packages), t, "number of packages")
+}
+
+func TestReadConfigurationConfigFileInvalidValue(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_CONFIG_FILE, writeConfigFile("github_user: Ma-Vin\npackages:\n  - name: a\n  - name: b\n    number_major_to_keep: -2\n  - delete_snapshots: true\n", "config.yaml", t))
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertTrue(strings.Contains(err.Error(), "packages[1].number_major_to_keep: '-2' is not valid, expected a positive integer"), t, "major error message")
+	assertValidationError("packages[1].number_major_to_keep", err, t)
+	assertValidationError("packages[2].name", err, t)
+	testutil.AssertNil(conf, t, "conf")
+}
+
+func TestReadConfigurationConfigFileInvalidValueAndEnv(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_CONFIG_FILE, writeConfigFile("github_user: Ma-Vin\npage_size: 0\npackages:\n  - name: a\n", "config.yaml", t))
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	os.Setenv(ENV_NAME_PACKAGE_TYPE, "maven")
+	os.Setenv(ENV_NAME_DRY_RUN, "maybe")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNotNil(err, t, "err")
+	assertValidationError("page_size", err, t)
+	assertValidationError(ENV_NAME_DRY_RUN, err, t)
+	testutil.AssertNil(conf, t, "conf")
+}
+
+func TestReadConfigurationConfigFileUnknownKey(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_CONFIG_FILE, writeConfigFile("github_user: Ma-Vin\nnumber_mayor_to_keep: 2\n", "config.yaml", t))
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertTrue(strings.Contains(err.Error(), "number_mayor_to_keep"), t, "error message")
+	testutil.AssertNil(conf, t, "conf")
+}
+
+func TestReadConfigurationConfigFilePackageName(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_CONFIG_FILE, writeConfigFile("github_user: Ma-Vin\npackage_name: DummyPackage\n", "config.yaml", t))
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertTrue(strings.Contains(err.Error(), "package_name"), t, "error message")
+	testutil.AssertNil(conf, t, "conf")
+}
+
+func TestReadConfigurationConfigFileMissing(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_CONFIG_FILE, filepath.Join(t.TempDir(), "missing.yaml"))
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertNil(conf, t, "conf")
+}
+
+func TestReadConfigurationConfigFilePackageWithoutRules(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_CONFIG_FILE, writeConfigFile("github_user: Ma-Vin\npackages:\n  - name: a\n", "config.yaml", t))
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNotNil(err, t, "err")
+	assertValidationError("packages[0]."+variableRulesToDelete, err, t)
+	testutil.AssertNil(conf, t, "conf")
+}
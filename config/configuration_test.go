@@ -1,7 +1,9 @@
 package config
 
 import (
+	"errors"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/ma-vin/testutil-go"
@@ -21,6 +23,7 @@ func unsetEnvWithPrefix(prefix string) {
 	os.Unsetenv(prefix + ENV_NAME_USER)
 	os.Unsetenv(prefix + ENV_NAME_PACKAGE_TYPE)
 	os.Unsetenv(prefix + ENV_NAME_PACKAGE_NAME)
+	os.Unsetenv(prefix + ENV_NAME_PACKAGE_NAME_PATTERN)
 	os.Unsetenv(prefix + ENV_NAME_VERSION_NAME_TO_DELETE)
 	os.Unsetenv(prefix + ENV_NAME_DELETE_SNAPSHOTS)
 	os.Unsetenv(prefix + ENV_NAME_NUMBER_MAJOR_TO_KEEP)
@@ -30,6 +33,51 @@ func unsetEnvWithPrefix(prefix string) {
 	os.Unsetenv(prefix + ENV_NAME_DRY_RUN)
 	os.Unsetenv(prefix + ENV_NAME_DEBUG)
 	os.Unsetenv(prefix + ENV_NAME_TIMEOUT)
+	os.Unsetenv(prefix + ENV_NAME_PAGE_SIZE)
+	os.Unsetenv(prefix + ENV_NAME_MAX_RETRIES)
+	os.Unsetenv(prefix + ENV_NAME_RETRY_MAX_WAIT)
+	os.Unsetenv(prefix + ENV_NAME_MAX_CONCURRENT_DELETIONS)
+	os.Unsetenv(prefix + ENV_NAME_DELETION_DELAY)
+	os.Unsetenv(prefix + ENV_NAME_REPORT_FILE)
+	os.Unsetenv(prefix + ENV_NAME_STEP_SUMMARY)
+	os.Unsetenv(prefix + ENV_NAME_OUTPUT)
+	os.Unsetenv(prefix + ENV_NAME_DELETE_UNTAGGED)
+	os.Unsetenv(prefix + ENV_NAME_TAG_PATTERN_TO_DELETE)
+	os.Unsetenv(prefix + ENV_NAME_PROTECTED_TAGS)
+	os.Unsetenv(prefix + ENV_NAME_MAX_AGE_SNAPSHOTS)
+	os.Unsetenv(prefix + ENV_NAME_MAX_AGE_RELEASES)
+	os.Unsetenv(prefix + ENV_NAME_MIN_AGE)
+	os.Unsetenv(prefix + ENV_NAME_NUMBER_NEWEST_TO_KEEP)
+	os.Unsetenv(prefix + ENV_NAME_VERSION_PATTERNS_TO_DELETE)
+	os.Unsetenv(prefix + ENV_NAME_VERSION_PATTERNS_TO_KEEP)
+	os.Unsetenv(prefix + ENV_NAME_EMPTY_PACKAGE_POLICY)
+	os.Unsetenv(prefix + ENV_NAME_MIN_VERSIONS_TO_KEEP)
+	os.Unsetenv(prefix + ENV_NAME_MAX_VERSIONS_TO_DELETE)
+	os.Unsetenv(prefix + ENV_NAME_FORCE)
+	os.Unsetenv(prefix + ENV_NAME_MODE)
+	os.Unsetenv(prefix + ENV_NAME_RESTORE_REPORT_FILE)
+	os.Unsetenv(prefix + ENV_NAME_RESTORE_VERSIONS)
+	os.Unsetenv(prefix + ENV_NAME_RESTORE_PACKAGE)
+	os.Unsetenv(prefix + ENV_NAME_CONFIG_FILE)
+	os.Unsetenv(prefix + ENV_NAME_PLAN_FILE)
+	os.Unsetenv(prefix + ENV_NAME_RUN_TIMEOUT)
+	os.Unsetenv(prefix + ENV_NAME_APP_ID)
+	os.Unsetenv(prefix + ENV_NAME_APP_INSTALLATION_ID)
+	os.Unsetenv(prefix + ENV_NAME_APP_PRIVATE_KEY)
+	os.Unsetenv(prefix + ENV_NAME_REPOSITORY)
+	os.Unsetenv(prefix + ENV_NAME_CURRENT_REPOSITORY)
+	os.Unsetenv(prefix + ENV_NAME_GITHUB_REPOSITORY)
+}
+
+func assertValidationError(variable string, err error, t *testing.T) {
+	var validationErrors ValidationErrors
+	testutil.AssertTrue(errors.As(err, &validationErrors), t, "validation errors")
+	for _, e := range validationErrors {
+		if e.Variable == variable {
+			return
+		}
+	}
+	t.Errorf("There is no validation error of %s at: %v", variable, err)
 }
 
 func TestReadConfigurationUserAndOrganization(t *testing.T) {
@@ -45,7 +93,7 @@ func TestReadConfigurationUserAndOrganization(t *testing.T) {
 	conf, err := ReadConfiguration()
 
 	testutil.AssertNotNil(err, t, "err")
-	testutil.AssertEquals("invalid configuration", err.Error(), t, "error message")
+	assertValidationError(variableOwner, err, t)
 	testutil.AssertNil(conf, t, "conf")
 }
 
@@ -60,7 +108,7 @@ func TestReadConfigurationMissingUserAndOrganization(t *testing.T) {
 	conf, err := ReadConfiguration()
 
 	testutil.AssertNotNil(err, t, "err")
-	testutil.AssertEquals("invalid configuration", err.Error(), t, "error message")
+	assertValidationError(variableOwner, err, t)
 	testutil.AssertNil(conf, t, "conf")
 }
 
@@ -91,6 +139,12 @@ func TestReadConfigurationUser(t *testing.T) {
 	testutil.AssertEquals(true, conf.DryRun, t, "dry run")
 	testutil.AssertEquals(false, conf.Debug, t, "debug log")
 	testutil.AssertEquals(3, conf.Timeout, t, "timeout")
+	testutil.AssertEquals(100, conf.PageSize, t, "page size")
+	testutil.AssertEquals(EMPTY_PACKAGE_KEEP_LATEST, conf.EmptyPackagePolicy, t, "empty package policy")
+	testutil.AssertEquals(-1, conf.MinVersionsToKeep, t, "min versions to keep")
+	testutil.AssertEquals(-1, conf.MaxVersionsToDelete, t, "max versions to delete")
+	testutil.AssertFalse(conf.Force, t, "force")
+	testutil.AssertEquals(MODE_DELETE, conf.Mode, t, "mode")
 }
 
 func TestReadConfigurationUserWithPrefix(t *testing.T) {
@@ -120,6 +174,7 @@ func TestReadConfigurationUserWithPrefix(t *testing.T) {
 	testutil.AssertEquals(true, conf.DryRun, t, "dry run")
 	testutil.AssertEquals(false, conf.Debug, t, "debug log")
 	testutil.AssertEquals(3, conf.Timeout, t, "timeout")
+	testutil.AssertEquals(100, conf.PageSize, t, "page size")
 }
 
 func TestReadConfigurationOrganization(t *testing.T) {
@@ -149,6 +204,7 @@ func TestReadConfigurationOrganization(t *testing.T) {
 	testutil.AssertEquals(true, conf.DryRun, t, "dry run")
 	testutil.AssertEquals(false, conf.Debug, t, "debug log")
 	testutil.AssertEquals(3, conf.Timeout, t, "timeout")
+	testutil.AssertEquals(100, conf.PageSize, t, "page size")
 }
 
 func TestReadConfigurationUnkownPackageType(t *testing.T) {
@@ -163,7 +219,7 @@ func TestReadConfigurationUnkownPackageType(t *testing.T) {
 	conf, err := ReadConfiguration()
 
 	testutil.AssertNotNil(err, t, "err")
-	testutil.AssertEquals("invalid configuration", err.Error(), t, "error message")
+	assertValidationError(ENV_NAME_PACKAGE_TYPE, err, t)
 	testutil.AssertNil(conf, t, "conf")
 }
 
@@ -178,7 +234,7 @@ func TestReadConfigurationMissingPackageName(t *testing.T) {
 	conf, err := ReadConfiguration()
 
 	testutil.AssertNotNil(err, t, "err")
-	testutil.AssertEquals("invalid configuration", err.Error(), t, "error message")
+	assertValidationError(variablePackage, err, t)
 	testutil.AssertNil(conf, t, "conf")
 }
 func TestReadConfigurationMissingToken(t *testing.T) {
@@ -192,7 +248,7 @@ func TestReadConfigurationMissingToken(t *testing.T) {
 	conf, err := ReadConfiguration()
 
 	testutil.AssertNotNil(err, t, "err")
-	testutil.AssertEquals("invalid configuration", err.Error(), t, "error message")
+	assertValidationError(ENV_NAME_GITHUB_TOKEN, err, t)
 	testutil.AssertNil(conf, t, "conf")
 }
 
@@ -207,7 +263,7 @@ func TestReadConfigurationNothingToDelete(t *testing.T) {
 	conf, err := ReadConfiguration()
 
 	testutil.AssertNotNil(err, t, "err")
-	testutil.AssertEquals("invalid configuration", err.Error(), t, "error message")
+	assertValidationError(variableRulesToDelete, err, t)
 	testutil.AssertNil(conf, t, "conf")
 }
 
@@ -223,7 +279,7 @@ func TestReadConfigurationDeleteSNaphostNegative(t *testing.T) {
 	conf, err := ReadConfiguration()
 
 	testutil.AssertNotNil(err, t, "err")
-	testutil.AssertEquals("invalid configuration", err.Error(), t, "error message")
+	assertValidationError(variableRulesToDelete, err, t)
 	testutil.AssertNil(conf, t, "conf")
 }
 
@@ -242,6 +298,7 @@ func TestReadConfigurationUserAllSet(t *testing.T) {
 	os.Setenv(ENV_NAME_DRY_RUN, "false")
 	os.Setenv(ENV_NAME_DEBUG, "true")
 	os.Setenv(ENV_NAME_TIMEOUT, "5")
+	os.Setenv(ENV_NAME_PAGE_SIZE, "50")
 
 	conf, err := ReadConfiguration()
 
@@ -261,6 +318,7 @@ func TestReadConfigurationUserAllSet(t *testing.T) {
 	testutil.AssertEquals(false, conf.DryRun, t, "dry run")
 	testutil.AssertEquals(true, conf.Debug, t, "debug log")
 	testutil.AssertEquals(5, conf.Timeout, t, "timeout")
+	testutil.AssertEquals(50, conf.PageSize, t, "page size")
 }
 
 func TestReadConfigurationInvalidInt(t *testing.T) {
@@ -278,22 +336,66 @@ func TestReadConfigurationInvalidInt(t *testing.T) {
 
 	conf, err := ReadConfiguration()
 
-	testutil.AssertNil(err, t, "err")
-	testutil.AssertNotNil(conf, t, "conf")
-	testutil.AssertEquals("https://api.github.com", conf.GitHubRestUrl, t, "GitHub rest url")
-	testutil.AssertEquals("", conf.Organization, t, "organization")
-	testutil.AssertEquals("Ma-Vin", conf.User, t, "user")
-	testutil.AssertEquals("maven", conf.PackageType, t, "package type")
-	testutil.AssertEquals("packages-action-app", conf.PackageName, t, "package name")
-	testutil.AssertEquals("1.2.3-SNAPSHOT", conf.VersionNameToDelete, t, "version name to delete")
-	testutil.AssertEquals(true, conf.DeleteSnapshots, t, "delete snapshots")
-	testutil.AssertEquals(-1, conf.NumberOfMajorVersionsToKeep, t, "number of major versions")
-	testutil.AssertEquals(-1, conf.NumberOfMinorVersionsToKeep, t, "number of minor versions")
-	testutil.AssertEquals(1, conf.NumberOfPatchVersionsToKeep, t, "number of patch versions")
-	testutil.AssertEquals("abcdef123", conf.GithubToken, t, "github token")
-	testutil.AssertEquals(true, conf.DryRun, t, "dry run")
-	testutil.AssertEquals(false, conf.Debug, t, "debug log")
-	testutil.AssertEquals(3, conf.Timeout, t, "timeout")
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertNil(conf, t, "conf")
+	var validationErrors ValidationErrors
+	testutil.AssertTrue(errors.As(err, &validationErrors), t, "validation errors")
+	testutil.AssertEquals(2, len(validationErrors), t, "number of validation errors")
+	testutil.AssertEquals(ValidationError{ENV_NAME_NUMBER_MAJOR_TO_KEEP, "-3", expectedPositive}, validationErrors[0], t, "first validation error")
+	testutil.AssertEquals(ValidationError{ENV_NAME_NUMBER_MINOR_TO_KEEP, "2abc", expectedPositive}, validationErrors[1], t, "second validation error")
+}
+
+func TestReadConfigurationInvalidBool(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(ENV_NAME_PACKAGE_TYPE, MAVEN)
+	os.Setenv(ENV_NAME_PACKAGE_NAME, "packages-action-app")
+	os.Setenv(ENV_NAME_DELETE_SNAPSHOTS, "yes")
+	os.Setenv(ENV_NAME_NUMBER_MAJOR_TO_KEEP, "3")
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertNil(conf, t, "conf")
+	testutil.AssertEquals("invalid configuration: DELETE_SNAPSHOTS: 'yes' is not valid, expected true or false", err.Error(), t, "error message")
+}
+
+func TestReadConfigurationInvalidIntWithPrefix(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(ENV_NAME_PACKAGE_TYPE, MAVEN)
+	os.Setenv(ENV_NAME_PACKAGE_NAME, "packages-action-app")
+	os.Setenv(ENV_GITHUB_PREFIX+ENV_NAME_NUMBER_MAJOR_TO_KEEP, "3")
+	os.Setenv(ENV_GITHUB_PREFIX+ENV_NAME_PAGE_SIZE, "x")
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertNil(conf, t, "conf")
+	assertValidationError(ENV_NAME_PAGE_SIZE, err, t)
+	testutil.AssertFalse(strings.Contains(err.Error(), ENV_NAME_NUMBER_MAJOR_TO_KEEP), t, "prefixed major is valid")
+}
+
+func TestReadConfigurationAllProblems(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_PACKAGE_TYPE, "gradle")
+	os.Setenv(ENV_NAME_MODE, "purge")
+	os.Setenv(ENV_NAME_TIMEOUT, "0")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertNil(conf, t, "conf")
+	assertValidationError(ENV_NAME_PACKAGE_TYPE, err, t)
+	assertValidationError(ENV_NAME_MODE, err, t)
+	assertValidationError(ENV_NAME_TIMEOUT, err, t)
+	assertValidationError(variableOwner, err, t)
+	assertValidationError(ENV_NAME_GITHUB_TOKEN, err, t)
 }
 
 func TestReadConfigurationWithGitHubUrl(t *testing.T) {
@@ -324,4 +426,640 @@ func TestReadConfigurationWithGitHubUrl(t *testing.T) {
 	testutil.AssertEquals(true, conf.DryRun, t, "dry run")
 	testutil.AssertEquals(false, conf.Debug, t, "debug log")
 	testutil.AssertEquals(3, conf.Timeout, t, "timeout")
+	testutil.AssertEquals(100, conf.PageSize, t, "page size")
+}
+
+func TestReadConfigurationPageSizeTooLarge(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(ENV_NAME_PACKAGE_TYPE, MAVEN)
+	os.Setenv(ENV_NAME_PACKAGE_NAME, "packages-action-app")
+	os.Setenv(ENV_NAME_DELETE_SNAPSHOTS, "TRUE")
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	os.Setenv(ENV_NAME_PAGE_SIZE, "101")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNotNil(err, t, "err")
+	assertValidationError(ENV_NAME_PAGE_SIZE, err, t)
+	testutil.AssertNil(conf, t, "conf")
+}
+
+func TestReadConfigurationNpmPackageType(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(ENV_NAME_PACKAGE_TYPE, "NPM")
+	os.Setenv(ENV_NAME_PACKAGE_NAME, "packages-action-app")
+	os.Setenv(ENV_NAME_DELETE_SNAPSHOTS, "TRUE")
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(conf, t, "conf")
+	testutil.AssertEquals(NPM, conf.PackageType, t, "package type")
+}
+
+func TestReadConfigurationContainer(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(ENV_NAME_PACKAGE_TYPE, "Container")
+	os.Setenv(ENV_NAME_PACKAGE_NAME, "packages-action-app")
+	os.Setenv(ENV_NAME_DELETE_UNTAGGED, "true")
+	os.Setenv(ENV_NAME_TAG_PATTERN_TO_DELETE, "^pr-\\d+$")
+	os.Setenv(ENV_NAME_PROTECTED_TAGS, "latest, stable\nmain")
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(conf, t, "conf")
+	testutil.AssertEquals(CONTAINER, conf.PackageType, t, "package type")
+	testutil.AssertEquals(true, conf.DeleteUntagged, t, "delete untagged")
+	testutil.AssertEquals("^pr-\\d+$", conf.TagPatternToDelete, t, "tag pattern to delete")
+	testutil.AssertEquals(3, len(conf.ProtectedTags), t, "number of protected tags")
+	testutil.AssertEquals("latest", conf.ProtectedTags[0], t, "first protected tag")
+	testutil.AssertEquals("stable", conf.ProtectedTags[1], t, "second protected tag")
+	testutil.AssertEquals("main", conf.ProtectedTags[2], t, "third protected tag")
+}
+
+func TestReadConfigurationContainerDefaultProtectedTag(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(ENV_NAME_PACKAGE_TYPE, DOCKER)
+	os.Setenv(ENV_NAME_PACKAGE_NAME, "packages-action-app")
+	os.Setenv(ENV_NAME_TAG_PATTERN_TO_DELETE, "^pr-")
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(conf, t, "conf")
+	testutil.AssertEquals(DOCKER, conf.PackageType, t, "package type")
+	testutil.AssertEquals(false, conf.DeleteUntagged, t, "delete untagged")
+	testutil.AssertEquals(1, len(conf.ProtectedTags), t, "number of protected tags")
+	testutil.AssertEquals("latest", conf.ProtectedTags[0], t, "protected tag")
+}
+
+func TestReadConfigurationContainerInvalidTagPattern(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(ENV_NAME_PACKAGE_TYPE, CONTAINER)
+	os.Setenv(ENV_NAME_PACKAGE_NAME, "packages-action-app")
+	os.Setenv(ENV_NAME_TAG_PATTERN_TO_DELETE, "pr-(")
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNotNil(err, t, "err")
+	assertValidationError(ENV_NAME_TAG_PATTERN_TO_DELETE, err, t)
+	testutil.AssertNil(conf, t, "conf")
+}
+
+func TestReadConfigurationAges(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(ENV_NAME_PACKAGE_TYPE, "maven")
+	os.Setenv(ENV_NAME_PACKAGE_NAME, "com.github.ma_vin.util.layer.model")
+	os.Setenv(ENV_NAME_MAX_AGE_SNAPSHOTS, "14")
+	os.Setenv(ENV_NAME_MAX_AGE_RELEASES, "730")
+	os.Setenv(ENV_NAME_MIN_AGE, "2")
+	os.Setenv(ENV_NAME_NUMBER_NEWEST_TO_KEEP, "5")
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(conf, t, "conf")
+	testutil.AssertEquals(14, conf.MaxAgeOfSnapshots, t, "max age of snapshots")
+	testutil.AssertEquals(730, conf.MaxAgeOfReleases, t, "max age of releases")
+	testutil.AssertEquals(2, conf.MinAge, t, "min age")
+	testutil.AssertEquals(5, conf.NumberOfNewestReleasesToKeep, t, "number of newest releases to keep")
+}
+
+func TestReadConfigurationOnlyMinAge(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(ENV_NAME_PACKAGE_TYPE, "maven")
+	os.Setenv(ENV_NAME_PACKAGE_NAME, "com.github.ma_vin.util.layer.model")
+	os.Setenv(ENV_NAME_MIN_AGE, "2")
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNotNil(err, t, "err")
+	assertValidationError(variableRulesToDelete, err, t)
+	testutil.AssertNil(conf, t, "conf")
+}
+
+func TestReadConfigurationVersionPatterns(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(ENV_NAME_PACKAGE_TYPE, "maven")
+	os.Setenv(ENV_NAME_PACKAGE_NAME, "com.github.ma_vin.util.layer.model")
+	os.Setenv(ENV_NAME_VERSION_PATTERNS_TO_DELETE, "*-feature-*\nregex:^0\\.")
+	os.Setenv(ENV_NAME_VERSION_PATTERNS_TO_KEEP, "*-LTS")
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(conf, t, "conf")
+	testutil.AssertEquals(2, len(conf.VersionPatternsToDelete), t, "number of patterns to delete")
+	testutil.AssertEquals("*-feature-*", conf.VersionPatternsToDelete[0], t, "first pattern to delete")
+	testutil.AssertEquals("regex:^0\\.", conf.VersionPatternsToDelete[1], t, "second pattern to delete")
+	testutil.AssertEquals(1, len(conf.VersionPatternsToKeep), t, "number of patterns to keep")
+	testutil.AssertEquals("*-LTS", conf.VersionPatternsToKeep[0], t, "pattern to keep")
+}
+
+func TestReadConfigurationInvalidVersionPatterns(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(ENV_NAME_PACKAGE_TYPE, "maven")
+	os.Setenv(ENV_NAME_PACKAGE_NAME, "com.github.ma_vin.util.layer.model")
+	os.Setenv(ENV_NAME_VERSION_PATTERNS_TO_DELETE, "*-feature-*")
+	os.Setenv(ENV_NAME_VERSION_PATTERNS_TO_KEEP, "[-LTS")
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNotNil(err, t, "err")
+	assertValidationError(ENV_NAME_VERSION_PATTERNS_TO_KEEP, err, t)
+	testutil.AssertNil(conf, t, "conf")
+
+	os.Setenv(ENV_NAME_VERSION_PATTERNS_TO_KEEP, "regex:(-LTS")
+
+	conf, err = ReadConfiguration()
+
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertNil(conf, t, "conf")
+}
+
+func TestReadConfigurationMultiplePackageNames(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(ENV_NAME_PACKAGE_TYPE, "maven")
+	os.Setenv(ENV_NAME_PACKAGE_NAME, "com.github.ma_vin.util.layer.model, com.github.ma_vin.util.layer.api\ncom.github.ma_vin.util.layer.processor")
+	os.Setenv(ENV_NAME_DELETE_SNAPSHOTS, "true")
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(conf, t, "conf")
+	testutil.AssertEquals("com.github.ma_vin.util.layer.model", conf.PackageName, t, "package name")
+	testutil.AssertEquals(3, len(conf.PackageNames), t, "number of package names")
+	testutil.AssertEquals("com.github.ma_vin.util.layer.model", conf.PackageNames[0], t, "first package name")
+	testutil.AssertEquals("com.github.ma_vin.util.layer.api", conf.PackageNames[1], t, "second package name")
+	testutil.AssertEquals("com.github.ma_vin.util.layer.processor", conf.PackageNames[2], t, "third package name")
+	testutil.AssertEquals("", conf.PackageNamePattern, t, "package name pattern")
+}
+
+func TestReadConfigurationPackageNamePattern(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(ENV_NAME_PACKAGE_TYPE, "maven")
+	os.Setenv(ENV_NAME_PACKAGE_NAME_PATTERN, "com.github.ma_vin.util.*")
+	os.Setenv(ENV_NAME_DELETE_SNAPSHOTS, "true")
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(conf, t, "conf")
+	testutil.AssertEquals("", conf.PackageName, t, "package name")
+	testutil.AssertEquals(0, len(conf.PackageNames), t, "number of package names")
+	testutil.AssertEquals("com.github.ma_vin.util.*", conf.PackageNamePattern, t, "package name pattern")
+}
+
+func TestReadConfigurationInvalidPackageNamePattern(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(ENV_NAME_PACKAGE_TYPE, "maven")
+	os.Setenv(ENV_NAME_PACKAGE_NAME_PATTERN, "regex:com.github.(")
+	os.Setenv(ENV_NAME_DELETE_SNAPSHOTS, "true")
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNotNil(err, t, "err")
+	assertValidationError(ENV_NAME_PACKAGE_NAME_PATTERN, err, t)
+	testutil.AssertNil(conf, t, "conf")
+}
+
+func TestReadConfigurationRetries(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(ENV_NAME_PACKAGE_TYPE, "maven")
+	os.Setenv(ENV_NAME_PACKAGE_NAME, "com.github.ma_vin.util.layer.model")
+	os.Setenv(ENV_NAME_DELETE_SNAPSHOTS, "true")
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(conf, t, "conf")
+	testutil.AssertEquals(3, conf.MaxRetries, t, "default max retries")
+	testutil.AssertEquals(60, conf.RetryMaxWait, t, "default retry max wait")
+
+	os.Setenv(ENV_NAME_MAX_RETRIES, "0")
+	os.Setenv(ENV_NAME_RETRY_MAX_WAIT, "120")
+
+	conf, err = ReadConfiguration()
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(conf, t, "conf")
+	testutil.AssertEquals(0, conf.MaxRetries, t, "max retries")
+	testutil.AssertEquals(120, conf.RetryMaxWait, t, "retry max wait")
+
+	os.Setenv(ENV_NAME_MAX_RETRIES, "-1")
+
+	conf, err = ReadConfiguration()
+
+	testutil.AssertNotNil(err, t, "err of negative value")
+	testutil.AssertNil(conf, t, "conf of negative value")
+	assertValidationError(ENV_NAME_MAX_RETRIES, err, t)
+
+	os.Setenv(ENV_NAME_MAX_RETRIES, "21")
+
+	conf, err = ReadConfiguration()
+
+	testutil.AssertNotNil(err, t, "err of too large value")
+	testutil.AssertNil(conf, t, "conf of too large value")
+	assertValidationError(ENV_NAME_MAX_RETRIES, err, t)
+}
+
+func TestReadConfigurationConcurrentDeletions(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(ENV_NAME_PACKAGE_TYPE, "maven")
+	os.Setenv(ENV_NAME_PACKAGE_NAME, "com.github.ma_vin.util.layer.model")
+	os.Setenv(ENV_NAME_DELETE_SNAPSHOTS, "true")
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(conf, t, "conf")
+	testutil.AssertEquals(5, conf.MaxConcurrentDeletions, t, "default max concurrent deletions")
+	testutil.AssertEquals(0, conf.DeletionDelay, t, "default deletion delay")
+
+	os.Setenv(ENV_NAME_MAX_CONCURRENT_DELETIONS, "2")
+	os.Setenv(ENV_NAME_DELETION_DELAY, "500")
+
+	conf, err = ReadConfiguration()
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(conf, t, "conf")
+	testutil.AssertEquals(2, conf.MaxConcurrentDeletions, t, "max concurrent deletions")
+	testutil.AssertEquals(500, conf.DeletionDelay, t, "deletion delay")
+}
+
+func TestReadConfigurationReport(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(ENV_NAME_PACKAGE_TYPE, "maven")
+	os.Setenv(ENV_NAME_PACKAGE_NAME, "com.github.ma_vin.util.layer.model")
+	os.Setenv(ENV_NAME_DELETE_SNAPSHOTS, "true")
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	os.Setenv(ENV_NAME_REPORT_FILE, "report.json")
+	os.Setenv(ENV_NAME_STEP_SUMMARY, "/home/runner/work/_temp/_runner_file_commands/step_summary")
+	os.Setenv(ENV_NAME_OUTPUT, "/home/runner/work/_temp/_runner_file_commands/set_output")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(conf, t, "conf")
+	testutil.AssertEquals("report.json", conf.ReportFile, t, "report file")
+	testutil.AssertEquals("/home/runner/work/_temp/_runner_file_commands/step_summary", conf.StepSummaryFile, t, "step summary file")
+	testutil.AssertEquals("/home/runner/work/_temp/_runner_file_commands/set_output", conf.OutputFile, t, "output file")
+}
+
+func TestReadConfigurationEmptyPackagePolicy(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(ENV_NAME_PACKAGE_TYPE, "maven")
+	os.Setenv(ENV_NAME_PACKAGE_NAME, "com.github.ma_vin.util.layer.model")
+	os.Setenv(ENV_NAME_DELETE_SNAPSHOTS, "true")
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+
+	for _, policy := range []string{EMPTY_PACKAGE_DELETE_PACKAGE, EMPTY_PACKAGE_KEEP_LATEST, EMPTY_PACKAGE_FAIL} {
+		os.Setenv(ENV_NAME_EMPTY_PACKAGE_POLICY, strings.ToUpper(policy))
+
+		conf, err := ReadConfiguration()
+
+		testutil.AssertNil(err, t, "err "+policy)
+		testutil.AssertNotNil(conf, t, "conf "+policy)
+		testutil.AssertEquals(policy, conf.EmptyPackagePolicy, t, "empty package policy "+policy)
+	}
+}
+
+func TestReadConfigurationUnknownEmptyPackagePolicy(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(ENV_NAME_PACKAGE_TYPE, "maven")
+	os.Setenv(ENV_NAME_PACKAGE_NAME, "com.github.ma_vin.util.layer.model")
+	os.Setenv(ENV_NAME_DELETE_SNAPSHOTS, "true")
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	os.Setenv(ENV_NAME_EMPTY_PACKAGE_POLICY, "delete-all")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertNil(conf, t, "conf")
+}
+
+func TestReadConfigurationSafetyGuards(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(ENV_NAME_PACKAGE_TYPE, "maven")
+	os.Setenv(ENV_NAME_PACKAGE_NAME, "com.github.ma_vin.util.layer.model")
+	os.Setenv(ENV_NAME_DELETE_SNAPSHOTS, "true")
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	os.Setenv(ENV_NAME_MIN_VERSIONS_TO_KEEP, "5")
+	os.Setenv(ENV_NAME_MAX_VERSIONS_TO_DELETE, "100")
+	os.Setenv(ENV_NAME_FORCE, "true")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(conf, t, "conf")
+	testutil.AssertEquals(5, conf.MinVersionsToKeep, t, "min versions to keep")
+	testutil.AssertEquals(100, conf.MaxVersionsToDelete, t, "max versions to delete")
+	testutil.AssertTrue(conf.Force, t, "force")
+}
+
+func TestReadConfigurationRunTimeout(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(ENV_NAME_PACKAGE_TYPE, "maven")
+	os.Setenv(ENV_NAME_PACKAGE_NAME, "com.github.ma_vin.util.layer.model")
+	os.Setenv(ENV_NAME_DELETE_SNAPSHOTS, "true")
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertEquals(-1, conf.RunTimeout, t, "default run timeout")
+
+	os.Setenv(ENV_NAME_RUN_TIMEOUT, "600")
+
+	conf, err = ReadConfiguration()
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertEquals(600, conf.RunTimeout, t, "run timeout")
+
+	os.Setenv(ENV_NAME_RUN_TIMEOUT, "0")
+
+	conf, err = ReadConfiguration()
+
+	testutil.AssertNil(conf, t, "conf")
+	assertValidationError(ENV_NAME_RUN_TIMEOUT, err, t)
+}
+
+func TestReadConfigurationRepository(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(ENV_NAME_PACKAGE_TYPE, "maven")
+	os.Setenv(ENV_NAME_DELETE_SNAPSHOTS, "true")
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	os.Setenv(ENV_NAME_REPOSITORY, "Ma-Vin/packages-action")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(conf, t, "conf")
+	testutil.AssertEquals("Ma-Vin/packages-action", conf.Repository, t, "repository")
+	testutil.AssertEquals(0, len(conf.PackageNames), t, "number of package names")
+}
+
+func TestReadConfigurationInvalidRepository(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(ENV_NAME_PACKAGE_TYPE, "maven")
+	os.Setenv(ENV_NAME_DELETE_SNAPSHOTS, "true")
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	os.Setenv(ENV_NAME_REPOSITORY, "packages-action")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNil(conf, t, "conf")
+	assertValidationError(ENV_NAME_REPOSITORY, err, t)
+}
+
+func TestReadConfigurationCurrentRepository(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(ENV_NAME_PACKAGE_TYPE, "maven")
+	os.Setenv(ENV_NAME_PACKAGE_NAME_PATTERN, "com.github.ma_vin.*")
+	os.Setenv(ENV_NAME_DELETE_SNAPSHOTS, "true")
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	os.Setenv(ENV_NAME_CURRENT_REPOSITORY, "true")
+	os.Setenv(ENV_NAME_GITHUB_REPOSITORY, "Ma-Vin/packages-action")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(conf, t, "conf")
+	testutil.AssertEquals("Ma-Vin/packages-action", conf.Repository, t, "repository")
+	testutil.AssertEquals("com.github.ma_vin.*", conf.PackageNamePattern, t, "package name pattern")
+}
+
+func TestReadConfigurationCurrentRepositoryMissing(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(ENV_NAME_PACKAGE_TYPE, "maven")
+	os.Setenv(ENV_NAME_DELETE_SNAPSHOTS, "true")
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	os.Setenv(ENV_NAME_CURRENT_REPOSITORY, "true")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNil(conf, t, "conf")
+	assertValidationError(ENV_NAME_GITHUB_REPOSITORY, err, t)
+}
+
+func TestReadConfigurationCurrentRepositoryTogetherWithRepository(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(ENV_NAME_PACKAGE_TYPE, "maven")
+	os.Setenv(ENV_NAME_DELETE_SNAPSHOTS, "true")
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	os.Setenv(ENV_NAME_REPOSITORY, "Ma-Vin/other")
+	os.Setenv(ENV_NAME_CURRENT_REPOSITORY, "true")
+	os.Setenv(ENV_NAME_GITHUB_REPOSITORY, "Ma-Vin/packages-action")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNil(conf, t, "conf")
+	assertValidationError(ENV_NAME_CURRENT_REPOSITORY, err, t)
+}
+
+func TestReadConfigurationRestoreReport(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(ENV_NAME_PACKAGE_TYPE, "maven")
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	os.Setenv(ENV_NAME_MODE, "Restore")
+	os.Setenv(ENV_NAME_RESTORE_REPORT_FILE, "report.json")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(conf, t, "conf")
+	testutil.AssertEquals(MODE_RESTORE, conf.Mode, t, "mode")
+	testutil.AssertEquals("report.json", conf.RestoreReportFile, t, "restore report file")
+	testutil.AssertEquals(0, len(conf.RestoreVersions), t, "len restore versions")
+	testutil.AssertFalse(conf.RestorePackage, t, "restore package")
+}
+
+func TestReadConfigurationRestoreVersions(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(ENV_NAME_PACKAGE_TYPE, "maven")
+	os.Setenv(ENV_NAME_PACKAGE_NAME, "com.github.ma_vin.util.layer.model")
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	os.Setenv(ENV_NAME_MODE, MODE_RESTORE)
+	os.Setenv(ENV_NAME_RESTORE_VERSIONS, "123, 1.0.0-SNAPSHOT")
+	os.Setenv(ENV_NAME_RESTORE_PACKAGE, "true")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(conf, t, "conf")
+	testutil.AssertEquals(2, len(conf.RestoreVersions), t, "len restore versions")
+	testutil.AssertEquals("123", conf.RestoreVersions[0], t, "first restore version")
+	testutil.AssertEquals("1.0.0-SNAPSHOT", conf.RestoreVersions[1], t, "second restore version")
+	testutil.AssertTrue(conf.RestorePackage, t, "restore package")
+}
+
+func TestReadConfigurationRestoreVersionsWithoutPackageName(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(ENV_NAME_PACKAGE_TYPE, "maven")
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	os.Setenv(ENV_NAME_MODE, MODE_RESTORE)
+	os.Setenv(ENV_NAME_RESTORE_VERSIONS, "123")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertNil(conf, t, "conf")
+}
+
+func TestReadConfigurationNothingToRestore(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(ENV_NAME_PACKAGE_TYPE, "maven")
+	os.Setenv(ENV_NAME_PACKAGE_NAME, "com.github.ma_vin.util.layer.model")
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	os.Setenv(ENV_NAME_MODE, MODE_RESTORE)
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertNil(conf, t, "conf")
+}
+
+func TestReadConfigurationUnknownMode(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(ENV_NAME_PACKAGE_TYPE, "maven")
+	os.Setenv(ENV_NAME_PACKAGE_NAME, "com.github.ma_vin.util.layer.model")
+	os.Setenv(ENV_NAME_DELETE_SNAPSHOTS, "true")
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	os.Setenv(ENV_NAME_MODE, "purge")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertNil(conf, t, "conf")
+}
+
+func TestReadConfigurationList(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(ENV_NAME_PACKAGE_TYPE, "maven")
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	os.Setenv(ENV_NAME_MODE, "list")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(conf, t, "conf")
+	testutil.AssertEquals(MODE_LIST, conf.Mode, t, "mode")
+}
+
+func TestReadConfigurationListMissingToken(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(ENV_NAME_PACKAGE_TYPE, "maven")
+	os.Setenv(ENV_NAME_MODE, "list")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertNil(conf, t, "conf")
+}
+
+func TestReadConfigurationApply(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(ENV_NAME_PACKAGE_TYPE, "maven")
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	os.Setenv(ENV_NAME_MODE, "apply")
+	os.Setenv(ENV_NAME_PLAN_FILE, "plan.json")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(conf, t, "conf")
+	testutil.AssertEquals(MODE_APPLY, conf.Mode, t, "mode")
+	testutil.AssertEquals("plan.json", conf.PlanFile, t, "plan file")
+}
+
+func TestReadConfigurationApplyMissingPlanFile(t *testing.T) {
+	unsetEnv()
+
+	os.Setenv(ENV_NAME_USER, "Ma-Vin")
+	os.Setenv(ENV_NAME_PACKAGE_TYPE, "maven")
+	os.Setenv(ENV_NAME_GITHUB_TOKEN, "abcdef123")
+	os.Setenv(ENV_NAME_MODE, "apply")
+
+	conf, err := ReadConfiguration()
+
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertNil(conf, t, "conf")
 }
@@ -0,0 +1,208 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	expectedPositive      = "a positive integer"
+	expectedNotNegative   = "an integer of at least 0"
+	expectedBool          = "true or false"
+	expectedPattern       = "a glob or, with prefix " + REGEX_PATTERN_PREFIX + ", a regular expression"
+	expectedRegex         = "a regular expression"
+	expectedPackageType   = MAVEN + ", " + NPM + ", " + CONTAINER + " or " + DOCKER
+	expectedEmptyPolicy   = EMPTY_PACKAGE_DELETE_PACKAGE + ", " + EMPTY_PACKAGE_KEEP_LATEST + " or " + EMPTY_PACKAGE_FAIL
+	expectedMode          = MODE_DELETE + ", " + MODE_RESTORE + ", " + MODE_LIST + " or " + MODE_APPLY
+	expectedRuleToDelete  = "at least one of VERSION_NAME_TO_DELETE, VERSION_PATTERNS_TO_DELETE, DELETE_SNAPSHOTS, DELETE_UNTAGGED, TAG_PATTERN_TO_DELETE, NUMBER_MAJOR_TO_KEEP, NUMBER_MINOR_TO_KEEP, NUMBER_PATCH_TO_KEEP, MAX_AGE_SNAPSHOTS or MAX_AGE_RELEASES"
+	expectedToRestore     = "at least one of RESTORE_REPORT_FILE, RESTORE_VERSIONS or RESTORE_PACKAGE"
+	expectedPrivateKey    = "an RSA private key in PEM format or the path of its file"
+	expectedRepository    = "the full name of a repository like owner/name"
+	variableOwner         = ENV_NAME_USER + " or " + ENV_NAME_ORGANIZATION
+	variablePackage       = ENV_NAME_PACKAGE_NAME + " or " + ENV_NAME_PACKAGE_NAME_PATTERN
+	variableRulesToDelete = "rules to delete"
+	variableToRestore     = "versions or packages to restore"
+)
+
+// A problem of a configuration value: the environment variable or key of the configuration file, its offending value and the expected form
+type ValidationError struct {
+	Variable string
+	Value    string
+	Expected string
+}
+
+func (e ValidationError) Error() string {
+	if e.Value == "" {
+		return fmt.Sprintf("%s: missing value, expected %s", e.Variable, e.Expected)
+	}
+	return fmt.Sprintf("%s: '%s' is not valid, expected %s", e.Variable, e.Value, e.Expected)
+}
+
+// All problems of a configuration
+type ValidationErrors []ValidationError
+
+func (errs ValidationErrors) Error() string {
+	messages := make([]string, len(errs))
+	for i, e := range errs {
+		messages[i] = e.Error()
+	}
+	return "invalid configuration: " + strings.Join(messages, "; ")
+}
+
+func (errs ValidationErrors) Unwrap() []error {
+	res := make([]error, len(errs))
+	for i, e := range errs {
+		res[i] = e
+	}
+	return res
+}
+
+// adds a problem, unless the same one is already known
+func (errs *ValidationErrors) add(variable string, value string, expected string) {
+	e := ValidationError{Variable: variable, Value: value, Expected: expected}
+	for _, existing := range *errs {
+		if existing == e {
+			return
+		}
+	}
+	*errs = append(*errs, e)
+}
+
+// adds a problem if a value is present but not positive
+func (errs *ValidationErrors) addIfNotPositive(variable string, value *int) {
+	if value != nil && *value <= 0 {
+		errs.add(variable, strconv.Itoa(*value), expectedPositive)
+	}
+}
+
+// adds a problem for each pattern which is neither a valid glob nor, with prefix "regex:", a valid regular expression
+func (errs *ValidationErrors) addIfPatternsInvalid(variable string, patterns []string) {
+	for _, pattern := range patterns {
+		var err error
+		if regex, isRegex := strings.CutPrefix(pattern, REGEX_PATTERN_PREFIX); isRegex {
+			_, err = regexp.Compile(regex)
+		} else {
+			_, err = path.Match(pattern, "")
+		}
+		if err != nil {
+			errs.add(variable, pattern, expectedPattern)
+		}
+	}
+}
+
+// adds a problem if a value is present but not a valid regular expression
+func (errs *ValidationErrors) addIfRegexInvalid(variable string, value string) {
+	if value == "" {
+		return
+	}
+	if _, err := regexp.Compile(value); err != nil {
+		errs.add(variable, value, expectedRegex)
+	}
+}
+
+// Validates the dependencies between the values of a configuration which was read. The problems of single values are already known
+func validate(config *Config, errs *ValidationErrors) {
+	if (config.Organization != "" && config.User != "") || (config.Organization == "" && config.User == "") {
+		errs.add(variableOwner, strings.Trim(config.User+", "+config.Organization, ", "), "exactly one of both")
+	}
+	if config.AppId != "" || config.AppInstallationId > 0 || config.AppPrivateKey != "" {
+		validateApp(config, errs)
+	} else if config.GithubToken == "" {
+		errs.add(ENV_NAME_GITHUB_TOKEN, "", "a token or a GitHub App to access GitHub")
+	}
+	if config.Repository != "" && !isRepositoryFullName(config.Repository) {
+		errs.add(ENV_NAME_REPOSITORY, config.Repository, expectedRepository)
+	}
+	if config.MaxRetries > maxRetries {
+		errs.add(ENV_NAME_MAX_RETRIES, strconv.Itoa(config.MaxRetries), fmt.Sprintf("an integer between 0 and %d", maxRetries))
+	}
+	if config.PageSize > maxPageSize {
+		errs.add(ENV_NAME_PAGE_SIZE, strconv.Itoa(config.PageSize), fmt.Sprintf("an integer between 1 and %d", maxPageSize))
+	}
+
+	switch config.Mode {
+	case MODE_DELETE:
+		validateDelete(config, errs)
+	case MODE_RESTORE:
+		validateRestore(config, errs)
+	case MODE_APPLY:
+		if config.PlanFile == "" {
+			errs.add(ENV_NAME_PLAN_FILE, "", "the path of a plan file to apply")
+		}
+	}
+}
+
+// checks whether a value is the full name of a repository: its owner and name separated by a slash
+func isRepositoryFullName(value string) bool {
+	owner, name, found := strings.Cut(value, "/")
+	return found && owner != "" && name != "" && !strings.Contains(name, "/")
+}
+
+// Validates the GitHub App whose installation token is used instead of the token: all of its values are required
+func validateApp(config *Config, errs *ValidationErrors) {
+	if config.AppId == "" {
+		errs.add(ENV_NAME_APP_ID, "", "the id or client id of the GitHub App")
+	}
+	if config.AppInstallationId <= 0 {
+		errs.add(ENV_NAME_APP_INSTALLATION_ID, "", "the id of the installation of the GitHub App")
+	}
+	if config.AppPrivateKey == "" {
+		errs.add(ENV_NAME_APP_PRIVATE_KEY, "", expectedPrivateKey)
+	}
+}
+
+// Validates a configuration of mode delete: there have to be packages and rules to delete. Package configurations of a configuration file are validated each
+func validateDelete(config *Config, errs *ValidationErrors) {
+	if len(config.PackageNames) == 0 && config.PackageNamePattern == "" && len(config.Packages) == 0 && config.Repository == "" {
+		errs.add(variablePackage, "", "at least one package name, a pattern or a repository")
+	}
+	errs.addIfPatternsInvalid(ENV_NAME_PACKAGE_NAME_PATTERN, nonEmpty(config.PackageNamePattern))
+	if len(config.Packages) == 0 {
+		validateRules(config, "", errs)
+		return
+	}
+	for i := range config.Packages {
+		validateRules(&config.Packages[i], fmt.Sprintf("packages[%d].", i), errs)
+	}
+}
+
+// Validates the rules of a configuration. Problems of package configurations are named by the prefixed key of the configuration file
+func validateRules(config *Config, prefix string, errs *ValidationErrors) {
+	if config.VersionNameToDelete == "" && !config.DeleteSnapshots && !config.DeleteUntagged && config.TagPatternToDelete == "" &&
+		config.NumberOfMajorVersionsToKeep <= 0 && config.NumberOfMinorVersionsToKeep <= 0 && config.NumberOfPatchVersionsToKeep <= 0 &&
+		config.MaxAgeOfSnapshots <= 0 && config.MaxAgeOfReleases <= 0 && len(config.VersionPatternsToDelete) == 0 {
+		errs.add(prefix+variableRulesToDelete, "", expectedRuleToDelete)
+	}
+	errs.addIfRegexInvalid(ruleVariable(prefix, ENV_NAME_TAG_PATTERN_TO_DELETE), config.TagPatternToDelete)
+	errs.addIfPatternsInvalid(ruleVariable(prefix, ENV_NAME_VERSION_PATTERNS_TO_DELETE), config.VersionPatternsToDelete)
+	errs.addIfPatternsInvalid(ruleVariable(prefix, ENV_NAME_VERSION_PATTERNS_TO_KEEP), config.VersionPatternsToKeep)
+}
+
+// Validates a configuration of mode restore: there has to be something to restore and the versions or packages need a package name
+func validateRestore(config *Config, errs *ValidationErrors) {
+	if config.RestoreReportFile == "" && len(config.RestoreVersions) == 0 && !config.RestorePackage {
+		errs.add(variableToRestore, "", expectedToRestore)
+	}
+	if (len(config.RestoreVersions) > 0 || config.RestorePackage) && len(config.PackageNames) == 0 {
+		errs.add(ENV_NAME_PACKAGE_NAME, "", "the packages of the versions or packages to restore")
+	}
+}
+
+// returns the name of a rule variable: the environment variable or, with a prefix of a package, the key of the configuration file
+func ruleVariable(prefix string, envName string) string {
+	if prefix == "" {
+		return envName
+	}
+	return prefix + strings.ToLower(envName)
+}
+
+// returns a list with the value or an empty list if the value is empty
+func nonEmpty(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return []string{value}
+}
@@ -6,3 +6,5 @@ require (
 	github.com/ma-vin/testutil-go v1.2.0
 	github.com/ma-vin/typewriter v1.3.0
 )
+
+require gopkg.in/yaml.v3 v3.0.1
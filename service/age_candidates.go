@@ -0,0 +1,74 @@
+package service
+
+import (
+	"time"
+
+	"github.com/ma-vin/packages-action/config"
+	"github.com/ma-vin/packages-action/service/github_model"
+	"github.com/ma-vin/packages-action/service/version_model"
+)
+
+const hoursPerDay = 24
+
+type CurrentTimeProvider func() time.Time
+
+var CurrentTimeExecutor CurrentTimeProvider = initCurrentTimeExecutor()
+
+func initCurrentTimeExecutor() CurrentTimeProvider {
+	return func() time.Time {
+		return time.Now()
+	}
+}
+
+// Determines the point in time of the last change of a version: the update time or, if it is not parseable, the creation time.
+// The boolean result indicates whether any of both could be parsed as RFC3339
+func determineLastChange(version *github_model.Version) (time.Time, bool) {
+	if updatedAt, err := time.Parse(time.RFC3339, version.UpdatedAt); err == nil {
+		return updatedAt, true
+	}
+	if createdAt, err := time.Parse(time.RFC3339, version.CreatedAt); err == nil {
+		return createdAt, true
+	}
+	return time.Time{}, false
+}
+
+// Checks whether the last change of a version is more than a given number of days before now. A non positive number of days is not evaluated
+func isOlderThan(version *github_model.Version, days int, now time.Time) bool {
+	if days <= 0 {
+		return false
+	}
+	lastChange, ok := determineLastChange(version)
+	return ok && now.Sub(lastChange) > time.Duration(days)*hoursPerDay*time.Hour
+}
+
+// Checks whether the last change of a version is less than the minimum age before now. Versions without parseable time are not considered as too young
+func isYoungerThanMinAge(version *github_model.Version, config *config.Config, now time.Time) bool {
+	if config.MinAge <= 0 {
+		return false
+	}
+	lastChange, ok := determineLastChange(version)
+	return ok && now.Sub(lastChange) < time.Duration(config.MinAge)*hoursPerDay*time.Hour
+}
+
+// Checks whether a release at given index is to delete by its age. Releases which are one of the newest releases to keep are not deleted
+func isReleaseAgeDelete(index *int, version *github_model.Version, parsedVersions *[]*version_model.Version, config *config.Config, now time.Time) bool {
+	return isCountableVersion((*parsedVersions)[*index]) && isOlderThan(version, config.MaxAgeOfReleases, now) &&
+		countGreaterVersions(index, parsedVersions) >= config.NumberOfNewestReleasesToKeep
+}
+
+// Checks whether a snapshot is to delete by its age
+func isSnapshotAgeDelete(version *github_model.Version, parsedVersion *version_model.Version, config *config.Config, now time.Time) bool {
+	return parsedVersion != nil && parsedVersion.IsSnapshot() && isOlderThan(version, config.MaxAgeOfSnapshots, now)
+}
+
+// Counts the not snapshot versions which are greater than the one at given index
+func countGreaterVersions(index *int, parsedVersions *[]*version_model.Version) int {
+	counter := 0
+	indexVersion := (*parsedVersions)[*index]
+	for _, v := range *parsedVersions {
+		if isCountableVersion(v) && v.Compare(indexVersion) > 0 {
+			counter++
+		}
+	}
+	return counter
+}
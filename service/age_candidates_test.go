@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ma-vin/testutil-go"
+)
+
+func TestDetermineCandidatesSnapshotMaxAge(t *testing.T) {
+	initCandidateTest()
+
+	candidatesConf.MaxAgeOfSnapshots = 14
+	candidateVersionOne.Name = "1.0.0-SNAPSHOT"
+	candidateVersionTwo.Name = "1.1.0-SNAPSHOT"
+	candidateVersionTwo.UpdatedAt = "2024-03-25T16:00:00Z"
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(candidates, t, "candidates")
+	testutil.AssertEquals(1, len(*candidates), t, "len candidates")
+	testutil.AssertEquals(2, (*candidates)[0].Id, t, "id")
+	testutil.AssertEquals(VERSION_CANDIDATE, (*candidates)[0].Type, t, "type")
+}
+
+func TestDetermineCandidatesReleaseMaxAge(t *testing.T) {
+	initCandidateTest()
+
+	candidatesConf.MaxAgeOfReleases = 17
+	candidateVersionThreee.CreatedAt = "2024-03-20T20:00:00Z"
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(candidates, t, "candidates")
+	testutil.AssertEquals(2, len(*candidates), t, "len candidates")
+	testutil.AssertEquals(2, (*candidates)[0].Id, t, "first id")
+	testutil.AssertEquals(3, (*candidates)[1].Id, t, "second id")
+}
+
+func TestDetermineCandidatesReleaseMaxAgeWithNewestToKeep(t *testing.T) {
+	initCandidateTest()
+
+	candidatesConf.MaxAgeOfReleases = 10
+	candidatesConf.NumberOfNewestReleasesToKeep = 2
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(candidates, t, "candidates")
+	testutil.AssertEquals(1, len(*candidates), t, "len candidates")
+	testutil.AssertEquals(2, (*candidates)[0].Id, t, "id")
+}
+
+func TestDetermineCandidatesReleaseMaxAgeInvalidTimes(t *testing.T) {
+	initCandidateTest()
+
+	candidatesConf.MaxAgeOfReleases = 10
+	candidateVersionOne.CreatedAt = "anytime"
+	candidateVersionOne.UpdatedAt = ""
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(candidates, t, "candidates")
+	testutil.AssertEquals(2, len(*candidates), t, "len candidates")
+	testutil.AssertEquals(3, (*candidates)[0].Id, t, "first id")
+	testutil.AssertEquals(4, (*candidates)[1].Id, t, "second id")
+}
+
+func TestDetermineCandidatesMinAge(t *testing.T) {
+	initCandidateTest()
+
+	candidatesConf.NumberOfPatchVersionsToKeep = 1
+	candidatesConf.NumberOfMinorVersionsToKeep = 1
+	candidatesConf.MinAge = 18
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(candidates, t, "candidates")
+	testutil.AssertEquals(1, len(*candidates), t, "len candidates")
+	testutil.AssertEquals(2, (*candidates)[0].Id, t, "id")
+}
+
+func TestDetermineCandidatesMinAgeVersionName(t *testing.T) {
+	initCandidateTest()
+
+	candidatesConf.VersionNameToDelete = "1.1.1"
+	candidatesConf.MinAge = 30
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(candidates, t, "candidates")
+	testutil.AssertEquals(1, len(*candidates), t, "len candidates")
+	testutil.AssertEquals(4, (*candidates)[0].Id, t, "id")
+}
+
+func TestDetermineContainerCandidatesMaxAge(t *testing.T) {
+	initContainerCandidateTest([]string{"1.0.0"}, []string{"1.1.0-rc.1"}, []string{"2.0.0", "latest"})
+
+	candidatesConf.MaxAgeOfReleases = 10
+	candidatesConf.MaxAgeOfSnapshots = 10
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(candidates, t, "candidates")
+	testutil.AssertEquals(2, len(*candidates), t, "len candidates")
+	testutil.AssertEquals(2, (*candidates)[0].Id, t, "first id")
+	testutil.AssertEquals(3, (*candidates)[1].Id, t, "second id")
+}
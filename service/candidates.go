@@ -1,12 +1,15 @@
 package service
 
 import (
+	"context"
 	"fmt"
-	"strconv"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/ma-vin/packages-action/config"
 	"github.com/ma-vin/packages-action/service/github_model"
+	"github.com/ma-vin/packages-action/service/version_model"
 	"github.com/ma-vin/typewriter/logger"
 )
 
@@ -16,37 +19,51 @@ const (
 )
 
 type Candidate struct {
-	Name        string
-	Id          int
-	Description string
-	CreatedAt   string
-	UpdatedAt   string
-	Type        int
+	Name         string
+	Id           int
+	Description  string
+	CreatedAt    string
+	UpdatedAt    string
+	Type         int
+	MatchedRules []MatchedRule
+	// Number of versions which are deleted together with a package candidate
+	NumberOfVersions int
 }
 
-type GitHubGetVersionsRestExecutor func(config *config.Config) (*[]github_model.Version, error)
-type GitHubGetPackageRestExecutor func(config *config.Config) (*github_model.UserPackage, error)
-type GitHubGetAllPackagesRestExecutor func(config *config.Config) (*[]github_model.UserPackage, error)
+// The candidates of a package and the names of its versions which are kept
+type PackageCandidates struct {
+	PackageName  string
+	Candidates   *[]Candidate
+	KeptVersions []string
+	// the empty package policy which was applied because all versions were to delete. Empty if there remain other versions
+	EmptyPackagePolicy string
+	// hash of the versions of the package the candidates were determined from
+	VersionsHash string
+}
+
+type GitHubGetVersionsRestExecutor func(ctx context.Context, config *config.Config) (*[]github_model.Version, error)
+type GitHubGetPackageRestExecutor func(ctx context.Context, config *config.Config) (*github_model.UserPackage, error)
+type GitHubGetAllPackagesRestExecutor func(ctx context.Context, config *config.Config) (*[]github_model.UserPackage, error)
 
 var VersionsGetExecutor GitHubGetVersionsRestExecutor = initVersionsGetExecutor()
 var PackageGetExecutor GitHubGetPackageRestExecutor = initPackageGetExecutor()
 var AllPackagesGetExecutor GitHubGetAllPackagesRestExecutor = initAllPackagesGetExecutor()
 
 func initVersionsGetExecutor() GitHubGetVersionsRestExecutor {
-	return func(config *config.Config) (*[]github_model.Version, error) {
-		return GetUserPackageVersions(config.PackageName, config)
+	return func(ctx context.Context, config *config.Config) (*[]github_model.Version, error) {
+		return GetUserPackageVersions(ctx, config.PackageName, config)
 	}
 }
 
 func initPackageGetExecutor() GitHubGetPackageRestExecutor {
-	return func(config *config.Config) (*github_model.UserPackage, error) {
-		return GetUserPackage(config.PackageName, config)
+	return func(ctx context.Context, config *config.Config) (*github_model.UserPackage, error) {
+		return GetUserPackage(ctx, config.PackageName, config)
 	}
 }
 
 func initAllPackagesGetExecutor() GitHubGetAllPackagesRestExecutor {
-	return func(config *config.Config) (*[]github_model.UserPackage, error) {
-		return GetUserPackages(config)
+	return func(ctx context.Context, config *config.Config) (*[]github_model.UserPackage, error) {
+		return GetUserPackages(ctx, config)
 	}
 }
 
@@ -54,172 +71,358 @@ func InitAllCandidates() {
 	VersionsGetExecutor = initVersionsGetExecutor()
 	PackageGetExecutor = initPackageGetExecutor()
 	AllPackagesGetExecutor = initAllPackagesGetExecutor()
+	CurrentTimeExecutor = initCurrentTimeExecutor()
+}
+
+// Determine the candidates to delete of all packages which are given by package names or matches the package name pattern.
+// Each package is handled with its own copy of the configuration whose package name is set to the handled one.
+// If there are package configurations of a configuration file, each of them is handled with its own rules. A package is only handled by the first matching one
+func DetermineAllCandidates(ctx context.Context, configuration *config.Config) (*[]PackageCandidates, error) {
+	return newExecutorCleaner(configuration).DetermineCandidates(ctx)
+}
+
+// Determine the candidates to delete of all packages of the configuration of the cleaner. See DetermineAllCandidates
+func (c *Cleaner) DetermineCandidates(ctx context.Context) (*[]PackageCandidates, error) {
+	configuration := c.configuration
+	packages, err := c.Repository.GetPackages(ctx, configuration)
+	if err != nil {
+		return nil, err
+	}
+	packageConfigs := configuration.Packages
+	if len(packageConfigs) == 0 {
+		packageConfigs = []config.Config{*configuration}
+	}
+
+	res := []PackageCandidates{}
+	handledPackageNames := []string{}
+	for i := range packageConfigs {
+		packageNames, err := determinePackageNames(packages, &packageConfigs[i])
+		if err != nil {
+			return nil, err
+		}
+		for _, packageName := range packageNames {
+			if slices.Contains(handledPackageNames, packageName) {
+				continue
+			}
+			handledPackageNames = append(handledPackageNames, packageName)
+			packageConfig := packageConfigs[i]
+			packageConfig.PackageName = packageName
+			packageCandidates, err := c.determineCandidatesOfExistingPackage(ctx, &packageConfig)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, *packageCandidates)
+		}
+	}
+	return &res, nil
+}
+
+// Determines the names of the existing packages which are either listed at the configuration or match its package name pattern.
+// If there is a repository, only packages linked to it are determined and, without names and pattern, all of them
+func determinePackageNames(packages *[]github_model.UserPackage, config *config.Config) ([]string, error) {
+	linkedPackages := filterPackagesOfRepository(packages, config)
+	var res []string
+	for _, packageName := range config.PackageNames {
+		if !containsPackage(packages, packageName) {
+			logPackageNotExisting(packageName, config)
+			continue
+		}
+		if !containsPackage(linkedPackages, packageName) {
+			logger.Warningf("The package %s is not linked to repository %s: skip deletion", packageName, config.Repository)
+			continue
+		}
+		if !slices.Contains(res, packageName) {
+			res = append(res, packageName)
+		}
+	}
+	if config.Repository != "" && len(config.PackageNames) == 0 && config.PackageNamePattern == "" {
+		return determineNamesOfRepositoryPackages(linkedPackages, config), nil
+	}
+	if config.PackageNamePattern == "" || packages == nil {
+		return res, nil
+	}
+	packages = linkedPackages
+	patterns, err := compilePatterns(&[]string{config.PackageNamePattern})
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range *packages {
+		if matchesAnyPattern(patterns, p.Name) && !slices.Contains(res, p.Name) {
+			res = append(res, p.Name)
+		}
+	}
+	if len(res) == 0 {
+		logger.Warningf("There does not exists any package of type %s matching pattern %s", config.PackageType, config.PackageNamePattern)
+	}
+	return res, nil
+}
+
+// Determines the packages which are linked to the repository of the configuration. Without a repository all packages are returned
+func filterPackagesOfRepository(packages *[]github_model.UserPackage, config *config.Config) *[]github_model.UserPackage {
+	if config.Repository == "" || packages == nil {
+		return packages
+	}
+	res := []github_model.UserPackage{}
+	for _, p := range *packages {
+		if strings.EqualFold(p.Repository.FullName, config.Repository) {
+			res = append(res, p)
+		}
+	}
+	return &res
+}
+
+// Determines the names of all packages which are linked to the repository of the configuration
+func determineNamesOfRepositoryPackages(linkedPackages *[]github_model.UserPackage, config *config.Config) []string {
+	var res []string
+	if linkedPackages != nil {
+		for _, p := range *linkedPackages {
+			res = append(res, p.Name)
+		}
+	}
+	if len(res) == 0 {
+		logger.Warningf("There does not exists any package of type %s linked to repository %s", config.PackageType, config.Repository)
+	}
+	return res
 }
 
 // Determine all candidates to delete. A candidate can be either a version or a package
 // If a package would be empty after version deletion, the package is to be deleted
-func DetermineCandidates(config *config.Config) (*[]Candidate, error) {
-	existence, err := checkPackageExistence(config)
+func DetermineCandidates(ctx context.Context, configuration *config.Config) (*[]Candidate, error) {
+	cleaner := newExecutorCleaner(configuration)
+	existence, err := cleaner.checkPackageExistence(ctx, configuration)
 	if err != nil {
 		return nil, err
 	}
 	if !existence {
-		logger.Warningf("There does not exists a package with name %s of type %s at user %s: skip deletion", config.PackageName, config.PackageType, config.User)
+		logPackageNotExisting(configuration.PackageName, configuration)
 		return &[]Candidate{}, nil
 	}
-
-	candidates, deletePackage, err := determineRelevantVersions(config)
+	packageCandidates, err := cleaner.determineCandidatesOfExistingPackage(ctx, configuration)
 	if err != nil {
 		return nil, err
 	}
-
-	if !deletePackage {
-		return candidates, nil
+	if packageCandidates.EmptyPackagePolicy == config.EMPTY_PACKAGE_FAIL {
+		return nil, fmt.Errorf("all versions of package %s are to delete, which is not allowed by empty package policy %s", configuration.PackageName, configuration.EmptyPackagePolicy)
 	}
+	return packageCandidates.Candidates, nil
+}
+
+// logs that the package does not exist at the owner
+func logPackageNotExisting(packageName string, config *config.Config) {
+	_, ownerName := getOwnerUrlParts(config)
+	logger.Warningf("There does not exists a package with name %s of type %s at owner %s: skip deletion", packageName, config.PackageType, ownerName)
+}
 
-	candidate, err := determineRelevantPackage(config)
+// Determine all candidates to delete of a package which is known to exist and the names of the versions which are kept.
+// If all versions are to delete, the empty package policy decides whether the package is deleted instead, the newest version is kept
+// or the deletion is to abort. The later one is left to the caller. Candidates which are one of the minimum number of newest versions to keep
+// are kept anyway, whichever selector selected them
+func (c *Cleaner) determineCandidatesOfExistingPackage(ctx context.Context, configuration *config.Config) (*PackageCandidates, error) {
+	versions, err := c.Repository.GetVersions(ctx, configuration)
 	if err != nil {
 		return nil, err
 	}
-	return &[]Candidate{*candidate}, nil
+	candidates, keptVersions, err := c.Selector.SelectVersions(versions, configuration)
+	if err != nil {
+		return nil, err
+	}
+	candidates, keptVersions = applyMinVersionsToKeep(versions, candidates, keptVersions, configuration)
+
+	res := PackageCandidates{PackageName: configuration.PackageName, Candidates: candidates, KeptVersions: keptVersions, VersionsHash: determineVersionsHash(versions)}
+	if len(*candidates) == 0 || len(keptVersions) > 0 {
+		return &res, nil
+	}
+
+	res.EmptyPackagePolicy = configuration.EmptyPackagePolicy
+	switch configuration.EmptyPackagePolicy {
+	case config.EMPTY_PACKAGE_DELETE_PACKAGE:
+		candidate, err := c.determineRelevantPackage(ctx, configuration)
+		if err != nil {
+			return nil, err
+		}
+		candidate.NumberOfVersions = len(*candidates)
+		res.Candidates = &[]Candidate{*candidate}
+	case config.EMPTY_PACKAGE_FAIL:
+		logger.Errorf("All versions of package %s are to delete, which is not allowed by empty package policy %s", configuration.PackageName, configuration.EmptyPackagePolicy)
+	default:
+		res.EmptyPackagePolicy = config.EMPTY_PACKAGE_KEEP_LATEST
+		keepNewestCandidate(&res)
+		logger.Informationf("All versions of package %s are to delete: keep newest version %s by empty package policy %s", configuration.PackageName, res.KeptVersions[0], res.EmptyPackagePolicy)
+	}
+	return &res, nil
 }
 
-// Checks whether there exists the package for the user
-func checkPackageExistence(config *config.Config) (bool, error) {
-	packages, err := AllPackagesGetExecutor(config)
+// Removes the candidate with the latest change from the candidates and adds it to the kept versions.
+// If there is no parseable point in time of the last change, the first candidate is kept (GitHub lists the newest version first)
+func keepNewestCandidate(packageCandidates *PackageCandidates) {
+	candidates := *packageCandidates.Candidates
+	newestIndex := 0
+	var newestChange time.Time
+	for i, c := range candidates {
+		lastChange, ok := determineLastChange(&github_model.Version{CreatedAt: c.CreatedAt, UpdatedAt: c.UpdatedAt})
+		if ok && lastChange.After(newestChange) {
+			newestIndex = i
+			newestChange = lastChange
+		}
+	}
+	packageCandidates.KeptVersions = append(packageCandidates.KeptVersions, candidates[newestIndex].Name)
+	remaining := slices.Delete(slices.Clone(candidates), newestIndex, newestIndex+1)
+	packageCandidates.Candidates = &remaining
+}
+
+// Checks whether there exists the package for the user or organization
+func (c *Cleaner) checkPackageExistence(ctx context.Context, config *config.Config) (bool, error) {
+	packages, err := c.Repository.GetPackages(ctx, config)
 	if err != nil {
 		return false, err
 	}
-	if packages == nil || len(*packages) == 0 {
-		return false, nil
+	return containsPackage(filterPackagesOfRepository(packages, config), config.PackageName), nil
+}
+
+// Checks whether there is a package with the given name
+func containsPackage(packages *[]github_model.UserPackage, packageName string) bool {
+	if packages == nil {
+		return false
 	}
 	for _, p := range *packages {
-		if p.Name == config.PackageName {
-			return true, nil
+		if p.Name == packageName {
+			return true
 		}
 	}
-	return false, nil
+	return false
 }
 
-// Determines all relevant versions which can be deleted and an indicator if package would be empty after version deletion
-func determineRelevantVersions(config *config.Config) (*[]Candidate, bool, error) {
-	versions, err := VersionsGetExecutor(config)
+// Determines all relevant versions which can be deleted at a given point in time and the names of the versions which are kept. If none is kept, the package would be empty after version deletion
+func determineRelevantVersions(versions *[]github_model.Version, config *config.Config, now time.Time) (*[]Candidate, []string, error) {
+	patterns, err := compileVersionPatterns(config)
 	if err != nil {
-		return nil, false, err
+		return nil, nil, err
 	}
 
-	versionNameParts, isSnapshot, err := splitVersionNames(versions)
-	if err != nil {
-		return nil, false, err
+	var res *[]Candidate
+	var keptVersions []string
+	if isContainerPackageType(config.PackageType) {
+		res, keptVersions, err = determineRelevantContainerVersions(versions, patterns, config, now)
+		if err != nil {
+			return nil, nil, err
+		}
+	} else {
+		res, keptVersions = determineRelevantNonContainerVersions(versions, patterns, config, now)
 	}
+	return res, keptVersions, nil
+}
 
-	var res []Candidate
+// Determines all relevant versions of a non container package which can be deleted and the names of the versions which are kept
+func determineRelevantNonContainerVersions(versions *[]github_model.Version, patterns *versionPatterns, config *config.Config, now time.Time) (*[]Candidate, []string) {
+	parsedVersions := parseVersionNames(versions, config.PackageType)
+
+	res := []Candidate{}
+	keptVersions := []string{}
 	for i, v := range *versions {
-		if isVersionRelevant(&i, versions, versionNameParts, isSnapshot, config) {
-			res = append(res, Candidate{v.Name, v.Id, v.Description, v.CreatedAt, v.UpdatedAt, VERSION_CANDIDATE})
+		if rules := determineMatchedRules(&i, versions, parsedVersions, patterns, config, now); len(rules) > 0 {
+			res = append(res, createVersionCandidate(&v, rules))
+		} else {
+			keptVersions = append(keptVersions, v.Name)
 		}
 	}
 
-	return &res, len(res) > 0 && len(*versions) == len(res), nil
+	return &res, keptVersions
+}
+
+// creates a candidate of a version with the rules it matched
+func createVersionCandidate(version *github_model.Version, rules []MatchedRule) Candidate {
+	return Candidate{Name: version.Name, Id: version.Id, Description: version.Description, CreatedAt: version.CreatedAt, UpdatedAt: version.UpdatedAt,
+		Type: VERSION_CANDIDATE, MatchedRules: rules}
 }
 
 // Determine the relevant package which is to be deleted
-func determineRelevantPackage(config *config.Config) (*Candidate, error) {
-	pack, err := PackageGetExecutor(config)
+func (c *Cleaner) determineRelevantPackage(ctx context.Context, config *config.Config) (*Candidate, error) {
+	pack, err := c.Repository.GetPackage(ctx, config)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Candidate{pack.Name, pack.Id, pack.Name, pack.CreatedAt, pack.UpdatedAt, PACKAGE_CANDIDATE}, nil
+	return &Candidate{Name: pack.Name, Id: pack.Id, Description: pack.Name, CreatedAt: pack.CreatedAt, UpdatedAt: pack.UpdatedAt, Type: PACKAGE_CANDIDATE,
+		MatchedRules: []MatchedRule{{RULE_ALL_VERSIONS, "all versions of the package are to delete"}}}, nil
 }
 
-// Checks if a version at a given index is to be deleted or not
-func isVersionRelevant(index *int, versions *[]github_model.Version, versionNameParts *[][]int, isSnapshot *[]bool, config *config.Config) bool {
-	isIndexSnapshot := (*isSnapshot)[*index]
-
-	versionNameMatch := config.VersionNameToDelete != "" && strings.EqualFold((*versions)[*index].Name, config.VersionNameToDelete)
-	snapshotDelete := config.DeleteSnapshots && isIndexSnapshot
-	deleteMajor := !isIndexSnapshot && config.NumberOfMajorVersionsToKeep > 0 && countCreaterMajorVersions(index, versionNameParts, isSnapshot) >= config.NumberOfMajorVersionsToKeep
-	deleteMinor := !isIndexSnapshot && config.NumberOfMinorVersionsToKeep > 0 && countCreaterMinorVersions(index, versionNameParts, isSnapshot) >= config.NumberOfMinorVersionsToKeep
-	deletePatch := !isIndexSnapshot && config.NumberOfPatchVersionsToKeep > 0 && countCreaterPatchVersions(index, versionNameParts, isSnapshot) >= config.NumberOfPatchVersionsToKeep
+// Determines the rules which match the version at a given index. If there is none, the version is not to delete. A version matching a pattern to keep is never deleted.
+// A version without parsed name is only relevant if its name matches the version name or a version pattern to delete.
+// Apart from the version name and patterns, no rule applies to versions younger than the minimum age
+func determineMatchedRules(index *int, versions *[]github_model.Version, parsedVersions *[]*version_model.Version, patterns *versionPatterns, config *config.Config, now time.Time) []MatchedRule {
+	version := &(*versions)[*index]
+	if patterns.isToKeep(version.Name) {
+		return nil
+	}
+	rules := determineNameRules(patterns, config, version.Name)
 
-	return versionNameMatch || snapshotDelete || deleteMajor || deleteMinor || deletePatch
+	if (*parsedVersions)[*index] == nil || len(rules) > 0 {
+		return rules
+	}
+	if isYoungerThanMinAge(version, config, now) {
+		return nil
+	}
+	return determineVersionRules(index, version, parsedVersions, config, now)
 }
 
-// Split the name of given versions into major, minor and patch tripel. In addition an indicator whether a version is a snapshot or not
-func splitVersionNames(versions *[]github_model.Version) (*[][]int, *[]bool, error) {
-	resSplit := make([][]int, len(*versions))
-	resSnapshot := make([]bool, len(*versions))
+// Parses the names of given versions depending on the package type: npm as semantic versions, others as maven versions.
+// Versions whose name can not be parsed are reported and skipped by a nil entry
+func parseVersionNames(versions *[]github_model.Version, packageType string) *[]*version_model.Version {
+	res := make([]*version_model.Version, len(*versions))
 
 	for i, v := range *versions {
-
-		nameToSplit, isSnaphot := strings.CutSuffix(strings.ToLower(v.Name), "-snapshot")
-		resSnapshot[i] = isSnaphot
-
-		parts := strings.Split(nameToSplit, ".")
-		if len(parts) > 3 {
-			return nil, nil, fmt.Errorf("there are more items than 'major.minor.patch' or 'major.minor.patch-SNAPSHOT' at version name '%s' with id %d", v.Name, v.Id)
-		}
-		err := addVersionPart(&parts, 0, "major", &i, &resSplit, &v)
-		if err != nil {
-			return nil, nil, err
-		}
-		err = addVersionPart(&parts, 1, "minor", &i, &resSplit, &v)
-		if err != nil {
-			return nil, nil, err
+		var err error
+		switch packageType {
+		case config.NPM:
+			res[i], err = version_model.ParseSemantic(v.Name)
+		default:
+			res[i], err = version_model.ParseMaven(v.Name)
 		}
-		err = addVersionPart(&parts, 2, "patch", &i, &resSplit, &v)
 		if err != nil {
-			return nil, nil, err
+			logger.Warningf("Skip version '%s' with id %d at major, minor, patch and snapshot rules: %v", v.Name, v.Id, err)
 		}
 	}
 
-	return &resSplit, &resSnapshot, nil
+	return &res
 }
 
-// adds the major, minor or patch number to splittedVersions for a given version index. there is none, zero will be set
-func addVersionPart(parts *[]string, partIndex int, partIndexName string, versionIndex *int, splittedVersions *[][]int, version *github_model.Version) error {
-	if len(*parts) > partIndex {
-		major, err := strconv.Atoi((*parts)[partIndex])
-		if err != nil {
-			return fmt.Errorf("failed to format %s version to int at version name '%s' with id %d: %v", partIndexName, version.Name, version.Id, err)
-		}
-		(*splittedVersions)[*versionIndex] = append((*splittedVersions)[*versionIndex], major)
-	} else {
-		(*splittedVersions)[*versionIndex] = append((*splittedVersions)[*versionIndex], 0)
-	}
-	return nil
-}
-
-// Counts the versions which have a greater major version than the one at given index
-func countCreaterMajorVersions(index *int, versionNameParts *[][]int, isSnapshot *[]bool) int {
+// Counts the not snapshot versions which have a greater major version than the one at given index
+func countCreaterMajorVersions(index *int, parsedVersions *[]*version_model.Version) int {
 	counter := 0
-	for i, parts := range *versionNameParts {
-		if !(*isSnapshot)[i] && parts[0] > (*versionNameParts)[*index][0] {
+	indexVersion := (*parsedVersions)[*index]
+	for _, v := range *parsedVersions {
+		if isCountableVersion(v) && v.Major() > indexVersion.Major() {
 			counter++
 		}
 	}
 	return counter
 }
 
-// Counts the versions which have equal major but greater minor version than the one at given index
-func countCreaterMinorVersions(index *int, versionNameParts *[][]int, isSnapshot *[]bool) int {
+// Counts the not snapshot versions which have equal major but greater minor version than the one at given index
+func countCreaterMinorVersions(index *int, parsedVersions *[]*version_model.Version) int {
 	counter := 0
-	for i, parts := range *versionNameParts {
-		if !(*isSnapshot)[i] && parts[0] == (*versionNameParts)[*index][0] && parts[1] > (*versionNameParts)[*index][1] {
+	indexVersion := (*parsedVersions)[*index]
+	for _, v := range *parsedVersions {
+		if isCountableVersion(v) && v.Major() == indexVersion.Major() && v.Minor() > indexVersion.Minor() {
 			counter++
 		}
 	}
 	return counter
 }
 
-// Counts the versions which have equal major and minor but greater patch version than the one at given index
-func countCreaterPatchVersions(index *int, versionNameParts *[][]int, isSnapshot *[]bool) int {
+// Counts the not snapshot versions which have equal major and minor but greater patch version than the one at given index
+func countCreaterPatchVersions(index *int, parsedVersions *[]*version_model.Version) int {
 	counter := 0
-	for i, parts := range *versionNameParts {
-		if !(*isSnapshot)[i] && parts[0] == (*versionNameParts)[*index][0] && parts[1] == (*versionNameParts)[*index][1] && parts[2] > (*versionNameParts)[*index][2] {
+	indexVersion := (*parsedVersions)[*index]
+	for _, v := range *parsedVersions {
+		if isCountableVersion(v) && v.Major() == indexVersion.Major() && v.Minor() == indexVersion.Minor() && v.Patch() > indexVersion.Patch() {
 			counter++
 		}
 	}
 	return counter
 }
+
+// Checks whether a parsed version is to consider at counting greater versions: it exists and it is not a snapshot
+func isCountableVersion(parsedVersion *version_model.Version) bool {
+	return parsedVersion != nil && !parsedVersion.IsSnapshot()
+}
@@ -1,8 +1,10 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/ma-vin/packages-action/config"
 	"github.com/ma-vin/packages-action/service/github_model"
@@ -24,17 +26,21 @@ func initCandidateTest() {
 
 	candidatePacakge = github_model.UserPackage{Id: 1, Name: "DummyPackage", CreatedAt: "2024-03-12T20:00:00Z", UpdatedAt: "2024-03-20:00:00Z"}
 
-	VersionsGetExecutor = func(config *config.Config) (*[]github_model.Version, error) {
+	VersionsGetExecutor = func(ctx context.Context, config *config.Config) (*[]github_model.Version, error) {
 		return &[]github_model.Version{candidateVersionOne, candidateVersionTwo, candidateVersionThreee}, nil
 	}
 
-	PackageGetExecutor = func(config *config.Config) (*github_model.UserPackage, error) {
+	PackageGetExecutor = func(ctx context.Context, config *config.Config) (*github_model.UserPackage, error) {
 		return &candidatePacakge, nil
 	}
 
-	AllPackagesGetExecutor = func(config *config.Config) (*[]github_model.UserPackage, error) {
+	AllPackagesGetExecutor = func(ctx context.Context, config *config.Config) (*[]github_model.UserPackage, error) {
 		return &[]github_model.UserPackage{candidatePacakge}, nil
 	}
+
+	CurrentTimeExecutor = func() time.Time {
+		return time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+	}
 }
 
 func TestDetermineCandidatesVersionName(t *testing.T) {
@@ -42,7 +48,7 @@ func TestDetermineCandidatesVersionName(t *testing.T) {
 
 	candidatesConf.VersionNameToDelete = "1.1.0"
 
-	candidates, err := DetermineCandidates(&candidatesConf)
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
 
 	testutil.AssertNil(err, t, "err")
 	testutil.AssertNotNil(candidates, t, "candidates")
@@ -57,7 +63,7 @@ func TestDetermineCandidatesSnapshot(t *testing.T) {
 	candidatesConf.DeleteSnapshots = true
 	candidateVersionTwo.Name = "1.1.0-SNAPSHOT"
 
-	candidates, err := DetermineCandidates(&candidatesConf)
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
 
 	testutil.AssertNil(err, t, "err")
 	testutil.AssertNotNil(candidates, t, "candidates")
@@ -71,7 +77,7 @@ func TestDetermineCandidatesMajorButAllTheSame(t *testing.T) {
 
 	candidatesConf.NumberOfMajorVersionsToKeep = 1
 
-	candidates, err := DetermineCandidates(&candidatesConf)
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
 
 	testutil.AssertNil(err, t, "err")
 	testutil.AssertNotNil(candidates, t, "candidates")
@@ -85,7 +91,7 @@ func TestDetermineCandidatesMajorAllDifferent(t *testing.T) {
 	candidateVersionTwo.Name = "2.0.0"
 	candidateVersionThreee.Name = "3.0.0"
 
-	candidates, err := DetermineCandidates(&candidatesConf)
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
 
 	testutil.AssertNil(err, t, "err")
 	testutil.AssertNotNil(candidates, t, "candidates")
@@ -103,7 +109,7 @@ func TestDetermineCandidatesMajorAllDifferentWithSnapshot(t *testing.T) {
 	candidateVersionTwo.Name = "2.0.0-SNAPSHOT"
 	candidateVersionThreee.Name = "3.0.0"
 
-	candidates, err := DetermineCandidates(&candidatesConf)
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
 
 	testutil.AssertNil(err, t, "err")
 	testutil.AssertNotNil(candidates, t, "candidates")
@@ -119,7 +125,7 @@ func TestDetermineCandidatesMinorButAllTheSame(t *testing.T) {
 	candidateVersionTwo.Name = "1.0.1"
 	candidateVersionThreee.Name = "1.0.2"
 
-	candidates, err := DetermineCandidates(&candidatesConf)
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
 
 	testutil.AssertNil(err, t, "err")
 	testutil.AssertNotNil(candidates, t, "candidates")
@@ -133,7 +139,7 @@ func TestDetermineCandidatesMinorAllDifferent(t *testing.T) {
 	candidateVersionTwo.Name = "1.1.0"
 	candidateVersionThreee.Name = "1.2.0"
 
-	candidates, err := DetermineCandidates(&candidatesConf)
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
 
 	testutil.AssertNil(err, t, "err")
 	testutil.AssertNotNil(candidates, t, "candidates")
@@ -151,7 +157,7 @@ func TestDetermineCandidatesMinorAllDifferentWithSnapshot(t *testing.T) {
 	candidateVersionTwo.Name = "1.1.0-SNAPSHOT"
 	candidateVersionThreee.Name = "1.2.0"
 
-	candidates, err := DetermineCandidates(&candidatesConf)
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
 
 	testutil.AssertNil(err, t, "err")
 	testutil.AssertNotNil(candidates, t, "candidates")
@@ -167,7 +173,7 @@ func TestDetermineCandidatesPatchButAllAtDifferentMinor(t *testing.T) {
 	candidateVersionTwo.Name = "1.1.1"
 	candidateVersionThreee.Name = "1.2.2"
 
-	candidates, err := DetermineCandidates(&candidatesConf)
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
 
 	testutil.AssertNil(err, t, "err")
 	testutil.AssertNotNil(candidates, t, "candidates")
@@ -181,7 +187,7 @@ func TestDetermineCandidatesPatchButAllAtDifferentMajor(t *testing.T) {
 	candidateVersionTwo.Name = "2.0.1"
 	candidateVersionThreee.Name = "3.0.2"
 
-	candidates, err := DetermineCandidates(&candidatesConf)
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
 
 	testutil.AssertNil(err, t, "err")
 	testutil.AssertNotNil(candidates, t, "candidates")
@@ -195,7 +201,7 @@ func TestDetermineCandidatesPatchAllDifferent(t *testing.T) {
 	candidateVersionTwo.Name = "1.0.1"
 	candidateVersionThreee.Name = "1.0.2"
 
-	candidates, err := DetermineCandidates(&candidatesConf)
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
 
 	testutil.AssertNil(err, t, "err")
 	testutil.AssertNotNil(candidates, t, "candidates")
@@ -213,7 +219,7 @@ func TestDetermineCandidatesPatchAllDifferentWithSnapshot(t *testing.T) {
 	candidateVersionTwo.Name = "1.0.1-SNAPSHOT"
 	candidateVersionThreee.Name = "1.0.2"
 
-	candidates, err := DetermineCandidates(&candidatesConf)
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
 
 	testutil.AssertNil(err, t, "err")
 	testutil.AssertNotNil(candidates, t, "candidates")
@@ -222,18 +228,20 @@ func TestDetermineCandidatesPatchAllDifferentWithSnapshot(t *testing.T) {
 	testutil.AssertEquals(VERSION_CANDIDATE, (*candidates)[0].Type, t, "type 1. entry candidates")
 }
 
-func TestDetermineCandidatesToManyVersionParts(t *testing.T) {
+func TestDetermineCandidatesMoreVersionParts(t *testing.T) {
 	initCandidateTest()
 
 	candidatesConf.NumberOfMajorVersionsToKeep = 1
 	candidateVersionTwo.Name = "2.0.0.1"
 	candidateVersionThreee.Name = "3.0.0"
 
-	candidates, err := DetermineCandidates(&candidatesConf)
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
 
-	testutil.AssertNotNil(err, t, "err")
-	testutil.AssertEquals("there are more items than 'major.minor.patch' or 'major.minor.patch-SNAPSHOT' at version name '2.0.0.1' with id 3", err.Error(), t, "err message")
-	testutil.AssertNil(candidates, t, "candidates")
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(candidates, t, "candidates")
+	testutil.AssertEquals(2, len(*candidates), t, "len candidates")
+	testutil.AssertEquals(2, (*candidates)[0].Id, t, "id 1. entry candidates")
+	testutil.AssertEquals(3, (*candidates)[1].Id, t, "id 2. entry candidates")
 }
 
 func TestDetermineCandidatesMajorAllDifferentMissingMinorPatch(t *testing.T) {
@@ -243,7 +251,7 @@ func TestDetermineCandidatesMajorAllDifferentMissingMinorPatch(t *testing.T) {
 	candidateVersionTwo.Name = "2.0"
 	candidateVersionThreee.Name = "3"
 
-	candidates, err := DetermineCandidates(&candidatesConf)
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
 
 	testutil.AssertNil(err, t, "err")
 	testutil.AssertNotNil(candidates, t, "candidates")
@@ -261,11 +269,12 @@ func TestDetermineCandidatesMajorInvalidMajor(t *testing.T) {
 	candidateVersionTwo.Name = "2.0.0"
 	candidateVersionThreee.Name = "3a.0.0"
 
-	candidates, err := DetermineCandidates(&candidatesConf)
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
 
-	testutil.AssertNotNil(err, t, "err")
-	testutil.AssertEquals("failed to format major version to int at version name '3a.0.0' with id 4: strconv.Atoi: parsing \"3a\": invalid syntax", err.Error(), t, "err message")
-	testutil.AssertNil(candidates, t, "candidates")
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(candidates, t, "candidates")
+	testutil.AssertEquals(1, len(*candidates), t, "len candidates")
+	testutil.AssertEquals(2, (*candidates)[0].Id, t, "id 1. entry candidates")
 }
 
 func TestDetermineCandidatesMajorInvalidMinor(t *testing.T) {
@@ -273,39 +282,77 @@ func TestDetermineCandidatesMajorInvalidMinor(t *testing.T) {
 
 	candidatesConf.NumberOfMajorVersionsToKeep = 1
 	candidateVersionTwo.Name = "2.0.0"
-	candidateVersionThreee.Name = "3.b.0"
+	candidateVersionThreee.Name = "3..0"
 
-	candidates, err := DetermineCandidates(&candidatesConf)
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
 
-	testutil.AssertNotNil(err, t, "err")
-	testutil.AssertEquals("failed to format minor version to int at version name '3.b.0' with id 4: strconv.Atoi: parsing \"b\": invalid syntax", err.Error(), t, "err message")
-	testutil.AssertNil(candidates, t, "candidates")
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(candidates, t, "candidates")
+	testutil.AssertEquals(1, len(*candidates), t, "len candidates")
+	testutil.AssertEquals(2, (*candidates)[0].Id, t, "id 1. entry candidates")
 }
 
-func TestDetermineCandidatesMajorInvalidPatch(t *testing.T) {
+func TestDetermineCandidatesMajorUnknownQualifier(t *testing.T) {
 	initCandidateTest()
 
 	candidatesConf.NumberOfMajorVersionsToKeep = 1
 	candidateVersionTwo.Name = "2.0.0"
 	candidateVersionThreee.Name = "3.0.c"
 
-	candidates, err := DetermineCandidates(&candidatesConf)
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
 
-	testutil.AssertNotNil(err, t, "err")
-	testutil.AssertEquals("failed to format patch version to int at version name '3.0.c' with id 4: strconv.Atoi: parsing \"c\": invalid syntax", err.Error(), t, "err message")
-	testutil.AssertNil(candidates, t, "candidates")
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(candidates, t, "candidates")
+	testutil.AssertEquals(2, len(*candidates), t, "len candidates")
+	testutil.AssertEquals(2, (*candidates)[0].Id, t, "id 1. entry candidates")
+	testutil.AssertEquals(3, (*candidates)[1].Id, t, "id 2. entry candidates")
+}
+
+func TestDetermineCandidatesInvalidVersionNameMatch(t *testing.T) {
+	initCandidateTest()
+
+	candidatesConf.NumberOfMajorVersionsToKeep = 1
+	candidatesConf.VersionNameToDelete = "nightly"
+	candidateVersionTwo.Name = "nightly"
+	candidateVersionThreee.Name = "3.0.0"
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(candidates, t, "candidates")
+	testutil.AssertEquals(2, len(*candidates), t, "len candidates")
+	testutil.AssertEquals(2, (*candidates)[0].Id, t, "id 1. entry candidates")
+	testutil.AssertEquals(3, (*candidates)[1].Id, t, "id 2. entry candidates")
+}
+
+func TestDetermineCandidatesMavenPrefixQualifierAndBuild(t *testing.T) {
+	initCandidateTest()
+
+	candidatesConf.NumberOfPatchVersionsToKeep = 1
+	candidatesConf.DeleteSnapshots = true
+	candidateVersionOne.Name = "v1.2.3"
+	candidateVersionTwo.Name = "1.2.4-RC1-SNAPSHOT"
+	candidateVersionThreee.Name = "1.2.4-RC1+build.5"
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(candidates, t, "candidates")
+	testutil.AssertEquals(2, len(*candidates), t, "len candidates")
+	testutil.AssertEquals(2, (*candidates)[0].Id, t, "id 1. entry candidates")
+	testutil.AssertEquals(3, (*candidates)[1].Id, t, "id 2. entry candidates")
 }
 
 func TestDetermineCandidatesGetVersionsWithError(t *testing.T) {
 	initCandidateTest()
 
-	VersionsGetExecutor = func(config *config.Config) (*[]github_model.Version, error) {
+	VersionsGetExecutor = func(ctx context.Context, config *config.Config) (*[]github_model.Version, error) {
 		return nil, errors.New("TestError")
 	}
 
 	candidatesConf.DeleteSnapshots = true
 
-	candidates, err := DetermineCandidates(&candidatesConf)
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
 
 	testutil.AssertNotNil(err, t, "err")
 	testutil.AssertEquals("TestError", err.Error(), t, "err message")
@@ -315,12 +362,13 @@ func TestDetermineCandidatesGetVersionsWithError(t *testing.T) {
 func TestDetermineCandidatesDeletePackage(t *testing.T) {
 	initCandidateTest()
 
+	candidatesConf.EmptyPackagePolicy = config.EMPTY_PACKAGE_DELETE_PACKAGE
 	candidatesConf.DeleteSnapshots = true
 	candidateVersionOne.Name = "1.0.0-SNAPSHOT"
 	candidateVersionTwo.Name = "2.0.0-SNAPSHOT"
 	candidateVersionThreee.Name = "3.0.0-SNAPSHOT"
 
-	candidates, err := DetermineCandidates(&candidatesConf)
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
 
 	testutil.AssertNil(err, t, "err")
 	testutil.AssertNotNil(candidates, t, "candidates")
@@ -332,7 +380,8 @@ func TestDetermineCandidatesDeletePackage(t *testing.T) {
 func TestDetermineCandidatesGetPackageWithError(t *testing.T) {
 	initCandidateTest()
 
-	PackageGetExecutor = func(config *config.Config) (*github_model.UserPackage, error) {
+	candidatesConf.EmptyPackagePolicy = config.EMPTY_PACKAGE_DELETE_PACKAGE
+	PackageGetExecutor = func(ctx context.Context, config *config.Config) (*github_model.UserPackage, error) {
 		return nil, errors.New("TestError")
 	}
 
@@ -341,9 +390,349 @@ func TestDetermineCandidatesGetPackageWithError(t *testing.T) {
 	candidateVersionTwo.Name = "2.0.0-SNAPSHOT"
 	candidateVersionThreee.Name = "3.0.0-SNAPSHOT"
 
-	candidates, err := DetermineCandidates(&candidatesConf)
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
 
 	testutil.AssertNotNil(err, t, "err")
 	testutil.AssertEquals("TestError", err.Error(), t, "err message")
 	testutil.AssertNil(candidates, t, "candidates")
 }
+
+func TestDetermineCandidatesKeepLatest(t *testing.T) {
+	initCandidateTest()
+
+	candidatesConf.PackageNames = []string{"DummyPackage"}
+	candidatesConf.EmptyPackagePolicy = config.EMPTY_PACKAGE_KEEP_LATEST
+	candidatesConf.DeleteSnapshots = true
+	candidateVersionOne.Name = "1.0.0-SNAPSHOT"
+	candidateVersionTwo.Name = "2.0.0-SNAPSHOT"
+	candidateVersionThreee.Name = "3.0.0-SNAPSHOT"
+
+	packageCandidates, err := DetermineAllCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(packageCandidates, t, "package candidates")
+	testutil.AssertEquals(1, len(*packageCandidates), t, "len package candidates")
+	candidates := (*packageCandidates)[0].Candidates
+	testutil.AssertEquals(2, len(*candidates), t, "len candidates")
+	testutil.AssertEquals(2, (*candidates)[0].Id, t, "first id")
+	testutil.AssertEquals(3, (*candidates)[1].Id, t, "second id")
+	testutil.AssertEquals(VERSION_CANDIDATE, (*candidates)[0].Type, t, "type")
+	testutil.AssertEquals(1, len((*packageCandidates)[0].KeptVersions), t, "len kept versions")
+	testutil.AssertEquals("3.0.0-SNAPSHOT", (*packageCandidates)[0].KeptVersions[0], t, "kept version")
+	testutil.AssertEquals(config.EMPTY_PACKAGE_KEEP_LATEST, (*packageCandidates)[0].EmptyPackagePolicy, t, "empty package policy")
+}
+
+func TestDetermineCandidatesKeepLatestByLastChange(t *testing.T) {
+	initCandidateTest()
+
+	candidatesConf.DeleteSnapshots = true
+	candidateVersionOne.Name = "1.0.0-SNAPSHOT"
+	candidateVersionOne.UpdatedAt = "2024-03-25T16:00:00Z"
+	candidateVersionTwo.Name = "2.0.0-SNAPSHOT"
+	candidateVersionThreee.Name = "3.0.0-SNAPSHOT"
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(candidates, t, "candidates")
+	testutil.AssertEquals(2, len(*candidates), t, "len candidates")
+	testutil.AssertEquals(3, (*candidates)[0].Id, t, "first id")
+	testutil.AssertEquals(4, (*candidates)[1].Id, t, "second id")
+}
+
+func TestDetermineCandidatesEmptyPackageFail(t *testing.T) {
+	initCandidateTest()
+
+	candidatesConf.PackageNames = []string{"DummyPackage"}
+	candidatesConf.EmptyPackagePolicy = config.EMPTY_PACKAGE_FAIL
+	candidatesConf.DeleteSnapshots = true
+	candidateVersionOne.Name = "1.0.0-SNAPSHOT"
+	candidateVersionTwo.Name = "2.0.0-SNAPSHOT"
+	candidateVersionThreee.Name = "3.0.0-SNAPSHOT"
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertNil(candidates, t, "candidates")
+
+	packageCandidates, err := DetermineAllCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err of all")
+	testutil.AssertNotNil(packageCandidates, t, "package candidates")
+	testutil.AssertEquals(3, len(*(*packageCandidates)[0].Candidates), t, "len candidates")
+	testutil.AssertEquals(0, len((*packageCandidates)[0].KeptVersions), t, "len kept versions")
+	testutil.AssertEquals(config.EMPTY_PACKAGE_FAIL, (*packageCandidates)[0].EmptyPackagePolicy, t, "empty package policy")
+}
+
+func TestDetermineCandidatesNpmPrerelease(t *testing.T) {
+	initCandidateTest()
+
+	candidatesConf.PackageType = config.NPM
+	candidatesConf.DeleteSnapshots = true
+	candidateVersionOne.Name = "1.4.0-beta.3"
+	candidateVersionTwo.Name = "2.0.0-rc.1"
+	candidateVersionThreee.Name = "2.0.0"
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(candidates, t, "candidates")
+	testutil.AssertEquals(2, len(*candidates), t, "len candidates")
+	testutil.AssertEquals(2, (*candidates)[0].Id, t, "id 1. entry candidates")
+	testutil.AssertEquals(3, (*candidates)[1].Id, t, "id 2. entry candidates")
+}
+
+func TestDetermineCandidatesNpmMajorWithBuildMetadata(t *testing.T) {
+	initCandidateTest()
+
+	candidatesConf.PackageType = config.NPM
+	candidatesConf.NumberOfMajorVersionsToKeep = 1
+	candidateVersionOne.Name = "1.0.0+build.5"
+	candidateVersionTwo.Name = "2.0.0-alpha-1+build.7"
+	candidateVersionThreee.Name = "2.1.0+build.8"
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(candidates, t, "candidates")
+	testutil.AssertEquals(1, len(*candidates), t, "len candidates")
+	testutil.AssertEquals(2, (*candidates)[0].Id, t, "id 1. entry candidates")
+}
+
+func TestDetermineCandidatesNpmSnapshotSuffixIsPrerelease(t *testing.T) {
+	initCandidateTest()
+
+	candidatesConf.PackageType = config.NPM
+	candidatesConf.NumberOfPatchVersionsToKeep = 1
+	candidateVersionOne.Name = "1.0.0"
+	candidateVersionTwo.Name = "1.0.1"
+	candidateVersionThreee.Name = "1.0.2-SNAPSHOT"
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(candidates, t, "candidates")
+	testutil.AssertEquals(1, len(*candidates), t, "len candidates")
+	testutil.AssertEquals(2, (*candidates)[0].Id, t, "id 1. entry candidates")
+}
+
+func TestDetermineCandidatesNpmMissingPatch(t *testing.T) {
+	initCandidateTest()
+
+	candidatesConf.PackageType = config.NPM
+	candidatesConf.NumberOfMajorVersionsToKeep = 1
+	candidateVersionTwo.Name = "2.0"
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(candidates, t, "candidates")
+	testutil.AssertEquals(0, len(*candidates), t, "len candidates")
+}
+
+func TestDetermineCandidatesNpmEmptyPrerelease(t *testing.T) {
+	initCandidateTest()
+
+	candidatesConf.PackageType = config.NPM
+	candidatesConf.NumberOfMajorVersionsToKeep = 1
+	candidateVersionTwo.Name = "2.0.0-"
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(candidates, t, "candidates")
+	testutil.AssertEquals(0, len(*candidates), t, "len candidates")
+}
+
+func initMultiplePackagesCandidateTest() {
+	initCandidateTest()
+
+	AllPackagesGetExecutor = func(ctx context.Context, config *config.Config) (*[]github_model.UserPackage, error) {
+		return &[]github_model.UserPackage{candidatePacakge, {Id: 5, Name: "OtherPackage"}, {Id: 6, Name: "AnotherPackage"}}, nil
+	}
+	VersionsGetExecutor = func(ctx context.Context, config *config.Config) (*[]github_model.Version, error) {
+		if config.PackageName == "OtherPackage" {
+			return &[]github_model.Version{candidateVersionOne, candidateVersionTwo}, nil
+		}
+		return &[]github_model.Version{candidateVersionOne, candidateVersionTwo, candidateVersionThreee}, nil
+	}
+
+	candidatesConf.NumberOfMinorVersionsToKeep = 1
+}
+
+func TestDetermineAllCandidatesPackageNames(t *testing.T) {
+	initMultiplePackagesCandidateTest()
+
+	candidatesConf.PackageNames = []string{"DummyPackage", "OtherPackage", "MissingPackage", "DummyPackage"}
+
+	packageCandidates, err := DetermineAllCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(packageCandidates, t, "packageCandidates")
+	testutil.AssertEquals(2, len(*packageCandidates), t, "len packageCandidates")
+	testutil.AssertEquals("DummyPackage", (*packageCandidates)[0].PackageName, t, "first package name")
+	testutil.AssertEquals(1, len(*(*packageCandidates)[0].Candidates), t, "len candidates of first package")
+	testutil.AssertEquals(2, (*(*packageCandidates)[0].Candidates)[0].Id, t, "candidate id of first package")
+	testutil.AssertEquals(2, len((*packageCandidates)[0].KeptVersions), t, "len kept versions of first package")
+	testutil.AssertEquals("1.1.0", (*packageCandidates)[0].KeptVersions[0], t, "first kept version of first package")
+	testutil.AssertEquals("1.1.1", (*packageCandidates)[0].KeptVersions[1], t, "second kept version of first package")
+	testutil.AssertEquals("OtherPackage", (*packageCandidates)[1].PackageName, t, "second package name")
+	testutil.AssertEquals(1, len(*(*packageCandidates)[1].Candidates), t, "len candidates of second package")
+	testutil.AssertEquals(1, len((*packageCandidates)[1].KeptVersions), t, "len kept versions of second package")
+	testutil.AssertEquals(2, (*(*packageCandidates)[1].Candidates)[0].Id, t, "candidate id of second package")
+}
+
+func TestDetermineAllCandidatesPackageNamePattern(t *testing.T) {
+	initMultiplePackagesCandidateTest()
+
+	candidatesConf.PackageNames = []string{"OtherPackage"}
+	candidatesConf.PackageNamePattern = "*package"
+
+	packageCandidates, err := DetermineAllCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(packageCandidates, t, "packageCandidates")
+	testutil.AssertEquals(3, len(*packageCandidates), t, "len packageCandidates")
+	testutil.AssertEquals("OtherPackage", (*packageCandidates)[0].PackageName, t, "first package name")
+	testutil.AssertEquals("DummyPackage", (*packageCandidates)[1].PackageName, t, "second package name")
+	testutil.AssertEquals("AnotherPackage", (*packageCandidates)[2].PackageName, t, "third package name")
+}
+
+// provides packages which are linked to repositories
+func initRepositoryPackages() {
+	AllPackagesGetExecutor = func(ctx context.Context, config *config.Config) (*[]github_model.UserPackage, error) {
+		dummyPackage := candidatePacakge
+		dummyPackage.Repository = github_model.Repository{FullName: "Ma-Vin/dummy-repo"}
+		return &[]github_model.UserPackage{dummyPackage, {Id: 5, Name: "OtherPackage", Repository: github_model.Repository{FullName: "Ma-Vin/other-repo"}},
+			{Id: 6, Name: "AnotherPackage", Repository: github_model.Repository{FullName: "ma-vin/Dummy-Repo"}}}, nil
+	}
+}
+
+func TestDetermineAllCandidatesRepository(t *testing.T) {
+	initMultiplePackagesCandidateTest()
+	initRepositoryPackages()
+
+	candidatesConf.Repository = "Ma-Vin/dummy-repo"
+
+	packageCandidates, err := DetermineAllCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(packageCandidates, t, "packageCandidates")
+	testutil.AssertEquals(2, len(*packageCandidates), t, "len packageCandidates")
+	testutil.AssertEquals("DummyPackage", (*packageCandidates)[0].PackageName, t, "first package name")
+	testutil.AssertEquals("AnotherPackage", (*packageCandidates)[1].PackageName, t, "second package name")
+}
+
+func TestDetermineAllCandidatesRepositoryWithPackageNamesAndPattern(t *testing.T) {
+	initMultiplePackagesCandidateTest()
+	initRepositoryPackages()
+
+	candidatesConf.Repository = "Ma-Vin/dummy-repo"
+	candidatesConf.PackageNames = []string{"OtherPackage", "DummyPackage"}
+	candidatesConf.PackageNamePattern = "Other*"
+
+	packageCandidates, err := DetermineAllCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(packageCandidates, t, "packageCandidates")
+	testutil.AssertEquals(1, len(*packageCandidates), t, "len packageCandidates")
+	testutil.AssertEquals("DummyPackage", (*packageCandidates)[0].PackageName, t, "first package name")
+}
+
+func TestDetermineAllCandidatesRepositoryWithoutPackages(t *testing.T) {
+	initMultiplePackagesCandidateTest()
+	initRepositoryPackages()
+
+	candidatesConf.Repository = "Ma-Vin/missing-repo"
+
+	packageCandidates, err := DetermineAllCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(packageCandidates, t, "packageCandidates")
+	testutil.AssertEquals(0, len(*packageCandidates), t, "len packageCandidates")
+}
+
+func TestDetermineAllCandidatesPackageNameRegexPattern(t *testing.T) {
+	initMultiplePackagesCandidateTest()
+
+	candidatesConf.PackageNames = []string{}
+	candidatesConf.PackageNamePattern = "regex:^(Other|Missing)"
+
+	packageCandidates, err := DetermineAllCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(packageCandidates, t, "packageCandidates")
+	testutil.AssertEquals(1, len(*packageCandidates), t, "len packageCandidates")
+	testutil.AssertEquals("OtherPackage", (*packageCandidates)[0].PackageName, t, "package name")
+}
+
+func TestDetermineAllCandidatesPackageConfigs(t *testing.T) {
+	initMultiplePackagesCandidateTest()
+
+	dummyConf := candidatesConf
+	dummyConf.PackageNames = []string{"DummyPackage"}
+	dummyConf.NumberOfMinorVersionsToKeep = -1
+	dummyConf.VersionNameToDelete = "1.1.1"
+	patternConf := candidatesConf
+	patternConf.PackageNames = []string{}
+	patternConf.PackageNamePattern = "*package"
+	candidatesConf.PackageNames = []string{"DummyPackage"}
+	candidatesConf.Packages = []config.Config{dummyConf, patternConf}
+
+	packageCandidates, err := DetermineAllCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(packageCandidates, t, "packageCandidates")
+	testutil.AssertEquals(3, len(*packageCandidates), t, "len packageCandidates")
+	testutil.AssertEquals("DummyPackage", (*packageCandidates)[0].PackageName, t, "first package name")
+	testutil.AssertEquals(1, len(*(*packageCandidates)[0].Candidates), t, "len candidates of first package")
+	testutil.AssertEquals(4, (*(*packageCandidates)[0].Candidates)[0].Id, t, "candidate id of first package")
+	testutil.AssertEquals("OtherPackage", (*packageCandidates)[1].PackageName, t, "second package name")
+	testutil.AssertEquals(1, len(*(*packageCandidates)[1].Candidates), t, "len candidates of second package")
+	testutil.AssertEquals(2, (*(*packageCandidates)[1].Candidates)[0].Id, t, "candidate id of second package")
+	testutil.AssertEquals("AnotherPackage", (*packageCandidates)[2].PackageName, t, "third package name")
+	testutil.AssertEquals(1, len(*(*packageCandidates)[2].Candidates), t, "len candidates of third package")
+	testutil.AssertEquals(2, (*(*packageCandidates)[2].Candidates)[0].Id, t, "candidate id of third package")
+}
+
+func TestDetermineAllCandidatesNoPackageMatch(t *testing.T) {
+	initMultiplePackagesCandidateTest()
+
+	candidatesConf.PackageNames = []string{}
+	candidatesConf.PackageNamePattern = "Missing*"
+
+	packageCandidates, err := DetermineAllCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(packageCandidates, t, "packageCandidates")
+	testutil.AssertEquals(0, len(*packageCandidates), t, "len packageCandidates")
+}
+
+func TestDetermineAllCandidatesGetPackagesError(t *testing.T) {
+	initMultiplePackagesCandidateTest()
+
+	candidatesConf.PackageNames = []string{"DummyPackage"}
+	AllPackagesGetExecutor = func(ctx context.Context, config *config.Config) (*[]github_model.UserPackage, error) {
+		return nil, errors.New("testError")
+	}
+
+	packageCandidates, err := DetermineAllCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertEquals("testError", err.Error(), t, "error message")
+	testutil.AssertNil(packageCandidates, t, "packageCandidates")
+}
+
+func TestDetermineAllCandidatesGetVersionsError(t *testing.T) {
+	initMultiplePackagesCandidateTest()
+
+	candidatesConf.PackageNames = []string{"DummyPackage"}
+	VersionsGetExecutor = func(ctx context.Context, config *config.Config) (*[]github_model.Version, error) {
+		return nil, errors.New("testError")
+	}
+
+	packageCandidates, err := DetermineAllCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertNil(packageCandidates, t, "packageCandidates")
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/ma-vin/packages-action/config"
+	"github.com/ma-vin/packages-action/service/github_model"
+)
+
+// Provides the packages and versions of the owner and package type of a configuration. A single package is the one of the configured package name
+type PackageRepository interface {
+	GetPackages(ctx context.Context, configuration *config.Config) (*[]github_model.UserPackage, error)
+	GetPackage(ctx context.Context, configuration *config.Config) (*github_model.UserPackage, error)
+	GetVersions(ctx context.Context, configuration *config.Config) (*[]github_model.Version, error)
+}
+
+// Selects the versions of a package which are to delete. The second result are the names of the versions which are kept
+type VersionSelector interface {
+	SelectVersions(versions *[]github_model.Version, configuration *config.Config) (*[]Candidate, []string, error)
+}
+
+// Deletes versions or whole packages of the owner and package type of a configuration
+type Deleter interface {
+	DeleteVersion(ctx context.Context, packageName string, versionId int, configuration *config.Config) error
+	DeletePackage(ctx context.Context, packageName string, configuration *config.Config) error
+}
+
+// Checks before any deletion whether the packages of the given names may be deleted
+type PermissionChecker interface {
+	CheckPermissions(ctx context.Context, packageNames []string, configuration *config.Config) error
+}
+
+// Cleans up the packages of a configuration. Each cleaner holds its own repository, selector and deleter, which are independent of
+// the package level executors
+type Cleaner struct {
+	configuration *config.Config
+	// source of the packages and their versions
+	Repository PackageRepository
+	// selection of the versions to delete
+	Selector VersionSelector
+	// executor of the deletions
+	Deleter Deleter
+	// preflight check of the permissions to delete. If nil, the permissions are not checked
+	PermissionChecker PermissionChecker
+
+	planWriter   PlanWriter
+	reportWriter ReportWriter
+	outputWriter OutputWriter
+	sleeper      Sleeper
+}
+
+// Creates a cleaner of a configuration which uses the GitHub rest api and selects the versions by the rules of the configuration.
+// Repository, selector, deleter and permission checker can be replaced before the cleaner is used
+func NewCleaner(configuration *config.Config) *Cleaner {
+	gitHub := NewGitHubRestClient()
+	return &Cleaner{
+		configuration:     configuration,
+		Repository:        gitHub,
+		Selector:          RulesSelector{CurrentTime: gitHub.CurrentTime},
+		Deleter:           gitHub,
+		PermissionChecker: gitHub,
+		planWriter:        WritePlan,
+		reportWriter:      WriteReport,
+		outputWriter:      WriteOutputs,
+		sleeper:           sleepContext,
+	}
+}
+
+// creates a cleaner of a configuration which delegates to the package level executors
+func newExecutorCleaner(configuration *config.Config) *Cleaner {
+	return &Cleaner{
+		configuration:     configuration,
+		Repository:        executorRepository{},
+		Selector:          RulesSelector{CurrentTime: CurrentTimeExecutor},
+		Deleter:           executorDeleter{},
+		PermissionChecker: newExecutorRestClient(),
+		planWriter:        PlanExecutor,
+		reportWriter:      ReportExecutor,
+		outputWriter:      OutputExecutor,
+		sleeper:           SleepExecutor,
+	}
+}
+
+// Repository, deleter and permission checker of the GitHub rest api. It is to create by NewGitHubRestClient, whose executor, sleeper and clock
+// can be replaced before the client is used. Copies of a client share its installation token cache
+type GitHubRestClient struct {
+	// executor of the http requests
+	ClientExecutor ClientExecutor
+	// waiting before a retry
+	Sleeper Sleeper
+	// clock of rate limit resets and the expiry of installation tokens
+	CurrentTime CurrentTimeProvider
+
+	installationTokens *installationTokenCache
+}
+
+// Creates a client of the GitHub rest api which sends its requests by a http client, waits by timers, uses the system clock and
+// has its own installation token cache
+func NewGitHubRestClient() GitHubRestClient {
+	return GitHubRestClient{ClientExecutor: initClientExector(), Sleeper: sleepContext, CurrentTime: time.Now, installationTokens: newInstallationTokenCache()}
+}
+
+func (client GitHubRestClient) GetPackages(ctx context.Context, configuration *config.Config) (*[]github_model.UserPackage, error) {
+	return client.getUserPackages(ctx, configuration)
+}
+
+func (client GitHubRestClient) GetPackage(ctx context.Context, configuration *config.Config) (*github_model.UserPackage, error) {
+	return client.getUserPackage(ctx, configuration.PackageName, configuration)
+}
+
+func (client GitHubRestClient) GetVersions(ctx context.Context, configuration *config.Config) (*[]github_model.Version, error) {
+	return client.getUserPackageVersions(ctx, configuration.PackageName, configuration)
+}
+
+func (client GitHubRestClient) DeleteVersion(ctx context.Context, packageName string, versionId int, configuration *config.Config) error {
+	return client.deleteUserPackageVersion(ctx, packageName, versionId, configuration)
+}
+
+func (client GitHubRestClient) DeletePackage(ctx context.Context, packageName string, configuration *config.Config) error {
+	return client.deleteUserPackage(ctx, packageName, configuration)
+}
+
+func (client GitHubRestClient) CheckPermissions(ctx context.Context, packageNames []string, configuration *config.Config) error {
+	return checkPermissions(ctx, packageNames, configuration, client.getTokenScopes)
+}
+
+// Selector of the versions which match the rules of the configuration, including the patterns to keep and the minimum number of versions to keep.
+// The ages of the versions are determined by its clock. If there is none, the system clock is used
+type RulesSelector struct {
+	CurrentTime CurrentTimeProvider
+}
+
+func (selector RulesSelector) SelectVersions(versions *[]github_model.Version, configuration *config.Config) (*[]Candidate, []string, error) {
+	now := time.Now()
+	if selector.CurrentTime != nil {
+		now = selector.CurrentTime()
+	}
+	return determineRelevantVersions(versions, configuration, now)
+}
+
+// repository which delegates to the package level executors
+type executorRepository struct{}
+
+func (executorRepository) GetPackages(ctx context.Context, configuration *config.Config) (*[]github_model.UserPackage, error) {
+	return AllPackagesGetExecutor(ctx, configuration)
+}
+
+func (executorRepository) GetPackage(ctx context.Context, configuration *config.Config) (*github_model.UserPackage, error) {
+	return PackageGetExecutor(ctx, configuration)
+}
+
+func (executorRepository) GetVersions(ctx context.Context, configuration *config.Config) (*[]github_model.Version, error) {
+	return VersionsGetExecutor(ctx, configuration)
+}
+
+// deleter which delegates to the package level executors
+type executorDeleter struct{}
+
+func (executorDeleter) DeleteVersion(ctx context.Context, packageName string, versionId int, configuration *config.Config) error {
+	return DeleteVersionExecutor(ctx, packageName, versionId, configuration)
+}
+
+func (executorDeleter) DeletePackage(ctx context.Context, packageName string, configuration *config.Config) error {
+	return DeletePackageExecutor(ctx, packageName, configuration)
+}
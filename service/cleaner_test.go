@@ -0,0 +1,280 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ma-vin/packages-action/config"
+	"github.com/ma-vin/packages-action/service/github_model"
+	"github.com/ma-vin/testutil-go"
+)
+
+// repository and deleter of a cleaner test which records the deletions
+type cleanerTestGitHub struct {
+	packages      []github_model.UserPackage
+	versions      []github_model.Version
+	mutex         sync.Mutex
+	deletedIds    []int
+	deletedByName []string
+	checkedNames  []string
+}
+
+func (g *cleanerTestGitHub) GetPackages(ctx context.Context, configuration *config.Config) (*[]github_model.UserPackage, error) {
+	return &g.packages, nil
+}
+
+func (g *cleanerTestGitHub) GetPackage(ctx context.Context, configuration *config.Config) (*github_model.UserPackage, error) {
+	for _, p := range g.packages {
+		if p.Name == configuration.PackageName {
+			return &p, nil
+		}
+	}
+	return nil, errors.New("package not found")
+}
+
+func (g *cleanerTestGitHub) GetVersions(ctx context.Context, configuration *config.Config) (*[]github_model.Version, error) {
+	return &g.versions, nil
+}
+
+func (g *cleanerTestGitHub) DeleteVersion(ctx context.Context, packageName string, versionId int, configuration *config.Config) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.deletedIds = append(g.deletedIds, versionId)
+	return nil
+}
+
+func (g *cleanerTestGitHub) DeletePackage(ctx context.Context, packageName string, configuration *config.Config) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.deletedByName = append(g.deletedByName, packageName)
+	return nil
+}
+
+func (g *cleanerTestGitHub) CheckPermissions(ctx context.Context, packageNames []string, configuration *config.Config) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.checkedNames = append(g.checkedNames, packageNames...)
+	return nil
+}
+
+// selector of a cleaner test which selects the versions of the given names
+type cleanerTestSelector struct {
+	names []string
+}
+
+func (s cleanerTestSelector) SelectVersions(versions *[]github_model.Version, configuration *config.Config) (*[]Candidate, []string, error) {
+	candidates := []Candidate{}
+	kept := []string{}
+	for _, v := range *versions {
+		if slices.Contains(s.names, v.Name) {
+			candidates = append(candidates, createVersionCandidate(&v, []MatchedRule{{RULE_VERSION_NAME, v.Name}}))
+		} else {
+			kept = append(kept, v.Name)
+		}
+	}
+	return &candidates, kept, nil
+}
+
+func newCleanerTestGitHub() *cleanerTestGitHub {
+	return &cleanerTestGitHub{
+		packages: []github_model.UserPackage{{Id: 1, Name: "DummyPackage"}, {Id: 2, Name: "OtherPackage"}},
+		versions: []github_model.Version{{Id: 11, Name: "1.0.0"}, {Id: 12, Name: "1.1.0"}, {Id: 13, Name: "2.0.0"}},
+	}
+}
+
+func initCleanerTest(t *testing.T) (*cleanerTestGitHub, config.Config) {
+	gitHub := newCleanerTestGitHub()
+	conf := config.Config{User: "DummyUser", PackageType: "maven", PackageNames: []string{"DummyPackage"}, PackageName: "DummyPackage",
+		EmptyPackagePolicy: config.EMPTY_PACKAGE_KEEP_LATEST, MaxConcurrentDeletions: 2}
+
+	// a cleaner does not use the package level executors
+	AllPackagesGetExecutor = func(ctx context.Context, config *config.Config) (*[]github_model.UserPackage, error) {
+		t.Error("package level executor used")
+		return nil, errors.New("package level executor used")
+	}
+	VersionsGetExecutor = func(ctx context.Context, config *config.Config) (*[]github_model.Version, error) {
+		t.Error("package level executor used")
+		return nil, errors.New("package level executor used")
+	}
+	DeleteVersionExecutor = func(ctx context.Context, packageName string, versionId int, config *config.Config) error {
+		t.Error("package level executor used")
+		return errors.New("package level executor used")
+	}
+	return gitHub, conf
+}
+
+func TestCleanerDeleteVersions(t *testing.T) {
+	gitHub, conf := initCleanerTest(t)
+	defer InitAllCandidates()
+	defer InitAllDeletion()
+
+	cleaner := NewCleaner(&conf)
+	cleaner.Repository = gitHub
+	cleaner.Deleter = gitHub
+	cleaner.PermissionChecker = gitHub
+	cleaner.Selector = cleanerTestSelector{names: []string{"1.0.0", "1.1.0"}}
+
+	err := cleaner.DeleteVersions(context.Background())
+
+	testutil.AssertNil(err, t, "err")
+	slices.Sort(gitHub.deletedIds)
+	testutil.AssertEquals(2, len(gitHub.deletedIds), t, "len deleted versions")
+	testutil.AssertEquals(11, gitHub.deletedIds[0], t, "first deleted version")
+	testutil.AssertEquals(12, gitHub.deletedIds[1], t, "second deleted version")
+	testutil.AssertEquals(0, len(gitHub.deletedByName), t, "len deleted packages")
+	testutil.AssertEquals(1, len(gitHub.checkedNames), t, "len checked packages")
+	testutil.AssertEquals("DummyPackage", gitHub.checkedNames[0], t, "checked package")
+}
+
+func TestCleanerDetermineCandidatesDefaultSelector(t *testing.T) {
+	gitHub, conf := initCleanerTest(t)
+	defer InitAllCandidates()
+	defer InitAllDeletion()
+	conf.VersionNameToDelete = "1.1.0"
+
+	cleaner := NewCleaner(&conf)
+	cleaner.Repository = gitHub
+
+	packageCandidates, err := cleaner.DetermineCandidates(context.Background())
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertEquals(1, len(*packageCandidates), t, "len package candidates")
+	testutil.AssertEquals(1, len(*(*packageCandidates)[0].Candidates), t, "len candidates")
+	testutil.AssertEquals(12, (*(*packageCandidates)[0].Candidates)[0].Id, t, "candidate id")
+}
+
+func TestCleanerDetermineCandidatesOwnClock(t *testing.T) {
+	gitHub, conf := initCleanerTest(t)
+	defer InitAllCandidates()
+	defer InitAllDeletion()
+	conf.MaxAgeOfReleases = 10
+	gitHub.versions = []github_model.Version{{Id: 11, Name: "1.0.0", UpdatedAt: "2024-01-01T00:00:00Z"}, {Id: 12, Name: "1.1.0", UpdatedAt: "2024-01-20T00:00:00Z"},
+		{Id: 13, Name: "2.0.0", UpdatedAt: "2024-01-25T00:00:00Z"}}
+	CurrentTimeExecutor = func() time.Time {
+		t.Error("package level clock used")
+		return time.Now()
+	}
+
+	cleaner := NewCleaner(&conf)
+	cleaner.Repository = gitHub
+	cleaner.Selector = RulesSelector{CurrentTime: func() time.Time {
+		return time.Date(2024, 1, 21, 0, 0, 0, 0, time.UTC)
+	}}
+
+	packageCandidates, err := cleaner.DetermineCandidates(context.Background())
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertEquals(1, len(*(*packageCandidates)[0].Candidates), t, "len candidates")
+	testutil.AssertEquals(11, (*(*packageCandidates)[0].Candidates)[0].Id, t, "candidate id")
+}
+
+func TestCleanerOwnRestClient(t *testing.T) {
+	_, conf := initCleanerTest(t)
+	defer InitAllCandidates()
+	defer InitAllDeletion()
+	defer InitAllGitHubRest()
+	conf.VersionNameToDelete = "1.0.0"
+	ClientRestExecutor = func(c *http.Client, req *http.Request) (*http.Response, error) {
+		t.Error("package level client executor used")
+		return nil, errors.New("package level client executor used")
+	}
+
+	client := NewGitHubRestClient()
+	var requestedUrls []string
+	client.ClientExecutor = func(c *http.Client, req *http.Request) (*http.Response, error) {
+		requestedUrls = append(requestedUrls, req.URL.Path)
+		body := `[{"id": 1, "name": "DummyPackage"}]`
+		if strings.HasSuffix(req.URL.Path, "/versions") {
+			body = `[{"id": 11, "name": "1.0.0"}, {"id": 12, "name": "1.1.0"}]`
+		}
+		return createResponse(&body, 200), nil
+	}
+	cleaner := NewCleaner(&conf)
+	cleaner.Repository = client
+
+	packageCandidates, err := cleaner.DetermineCandidates(context.Background())
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertEquals(2, len(requestedUrls), t, "number of requests")
+	testutil.AssertEquals(1, len(*(*packageCandidates)[0].Candidates), t, "len candidates")
+	testutil.AssertEquals(11, (*(*packageCandidates)[0].Candidates)[0].Id, t, "candidate id")
+}
+
+func TestCleanerDeleteVersionsEmptyPackage(t *testing.T) {
+	gitHub, conf := initCleanerTest(t)
+	defer InitAllCandidates()
+	defer InitAllDeletion()
+	conf.EmptyPackagePolicy = config.EMPTY_PACKAGE_DELETE_PACKAGE
+
+	cleaner := NewCleaner(&conf)
+	cleaner.Repository = gitHub
+	cleaner.Deleter = gitHub
+	cleaner.PermissionChecker = gitHub
+	cleaner.Selector = cleanerTestSelector{names: []string{"1.0.0", "1.1.0", "2.0.0"}}
+
+	err := cleaner.DeleteVersions(context.Background())
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertEquals(0, len(gitHub.deletedIds), t, "len deleted versions")
+	testutil.AssertEquals(1, len(gitHub.deletedByName), t, "len deleted packages")
+	testutil.AssertEquals("DummyPackage", gitHub.deletedByName[0], t, "deleted package")
+}
+
+func TestCleanerDeleteVersionsMinVersionsToKeepCustomSelector(t *testing.T) {
+	gitHub, conf := initCleanerTest(t)
+	defer InitAllCandidates()
+	defer InitAllDeletion()
+	conf.MinVersionsToKeep = 2
+	gitHub.versions = []github_model.Version{{Id: 11, Name: "1.0.0", UpdatedAt: "2024-03-12T20:00:00Z"}, {Id: 12, Name: "1.1.0", UpdatedAt: "2024-03-13T20:00:00Z"},
+		{Id: 13, Name: "2.0.0", UpdatedAt: "2024-03-14T20:00:00Z"}}
+
+	cleaner := NewCleaner(&conf)
+	cleaner.Repository = gitHub
+	cleaner.Deleter = gitHub
+	cleaner.PermissionChecker = gitHub
+	cleaner.Selector = cleanerTestSelector{names: []string{"1.0.0", "1.1.0", "2.0.0"}}
+
+	err := cleaner.DeleteVersions(context.Background())
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertEquals(1, len(gitHub.deletedIds), t, "len deleted versions")
+	testutil.AssertEquals(11, gitHub.deletedIds[0], t, "deleted version")
+	testutil.AssertEquals(0, len(gitHub.deletedByName), t, "len deleted packages")
+}
+
+func TestCleanerConcurrentUse(t *testing.T) {
+	gitHub, conf := initCleanerTest(t)
+	defer InitAllCandidates()
+	defer InitAllDeletion()
+	otherGitHub, otherConf := newCleanerTestGitHub(), conf
+	otherConf.PackageNames = []string{"OtherPackage"}
+	otherConf.PackageName = "OtherPackage"
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i, g := range []*cleanerTestGitHub{gitHub, otherGitHub} {
+		c := []*config.Config{&conf, &otherConf}[i]
+		cleaner := NewCleaner(c)
+		cleaner.Repository = g
+		cleaner.Deleter = g
+		cleaner.PermissionChecker = g
+		cleaner.Selector = cleanerTestSelector{names: []string{"1.0.0"}}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = cleaner.DeleteVersions(context.Background())
+		}()
+	}
+	wg.Wait()
+
+	testutil.AssertNil(errs[0], t, "first err")
+	testutil.AssertNil(errs[1], t, "second err")
+	testutil.AssertEquals(1, len(gitHub.deletedIds), t, "len deleted versions of first cleaner")
+	testutil.AssertEquals(1, len(otherGitHub.deletedIds), t, "len deleted versions of second cleaner")
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ma-vin/packages-action/config"
+	"github.com/ma-vin/packages-action/service/github_model"
+	"github.com/ma-vin/packages-action/service/version_model"
+)
+
+// Checks whether a package type is a container or docker image
+func isContainerPackageType(packageType string) bool {
+	return packageType == config.CONTAINER || packageType == config.DOCKER
+}
+
+// Determines all relevant container versions which can be deleted and the names of the versions which are kept.
+// The relevance is determined by the tags of the versions
+func determineRelevantContainerVersions(versions *[]github_model.Version, patterns *versionPatterns, config *config.Config, now time.Time) (*[]Candidate, []string, error) {
+	var tagPattern *regexp.Regexp
+	if config.TagPatternToDelete != "" {
+		var err error
+		tagPattern, err = regexp.Compile(config.TagPatternToDelete)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	versionTags := make([]*version_model.Version, len(*versions))
+	for i, v := range *versions {
+		versionTags[i] = determineSemanticVersionTag(getTags(&v))
+	}
+
+	res := []Candidate{}
+	keptVersions := []string{}
+	for i, v := range *versions {
+		if rules := determineContainerMatchedRules(&i, versions, &versionTags, tagPattern, patterns, config, now); len(rules) > 0 {
+			res = append(res, createVersionCandidate(&v, rules))
+		} else {
+			keptVersions = append(keptVersions, v.Name)
+		}
+	}
+
+	return &res, keptVersions, nil
+}
+
+// Determines the rules which match the container version at a given index. If there is none, the version is not to delete. Versions with a protected tag
+// or whose name or a tag matches a pattern to keep are never deleted. The number of major, minor and patch versions to keep is evaluated against semantic
+// version tags without prerelease. Apart from the version name and patterns, no rule applies to versions younger than the minimum age
+func determineContainerMatchedRules(index *int, versions *[]github_model.Version, versionTags *[]*version_model.Version, tagPattern *regexp.Regexp, patterns *versionPatterns, config *config.Config, now time.Time) []MatchedRule {
+	version := &(*versions)[*index]
+	tags := getTags(version)
+	names := append([]string{version.Name}, tags...)
+	if hasProtectedTag(&tags, config) || patterns.isToKeep(names...) {
+		return nil
+	}
+	if rules := determineNameRules(patterns, config, names...); len(rules) > 0 {
+		return rules
+	}
+	if isYoungerThanMinAge(version, config, now) {
+		return nil
+	}
+
+	var rules []MatchedRule
+	if config.DeleteUntagged && len(tags) == 0 {
+		rules = append(rules, MatchedRule{RULE_UNTAGGED, "version has no tags"})
+	}
+	if tagPattern != nil && allTagsMatch(&tags, tagPattern) {
+		rules = append(rules, MatchedRule{RULE_TAG_PATTERN, fmt.Sprintf("all tags '%s' match '%s'", strings.Join(tags, ", "), tagPattern.String())})
+	}
+	if (*versionTags)[*index] != nil {
+		rules = append(rules, determineVersionRules(index, version, versionTags, config, now)...)
+	}
+	return rules
+}
+
+// returns the tags of a container or docker version
+func getTags(version *github_model.Version) []string {
+	return append(slices.Clone(version.Metadata.Container.Tags), version.Metadata.Docker.Tags...)
+}
+
+// checks whether one of the tags is protected
+func hasProtectedTag(tags *[]string, config *config.Config) bool {
+	for _, tag := range *tags {
+		if slices.Contains(config.ProtectedTags, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// checks whether there are tags and all of them match the pattern
+func allTagsMatch(tags *[]string, tagPattern *regexp.Regexp) bool {
+	if len(*tags) == 0 {
+		return false
+	}
+	for _, tag := range *tags {
+		if !tagPattern.MatchString(tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// Determines the greatest semantic version of the tags (with optional prefix "v"). If there is none, nil is returned
+func determineSemanticVersionTag(tags []string) *version_model.Version {
+	var result *version_model.Version
+	for _, tag := range tags {
+		parsed, err := version_model.ParseSemantic(tag)
+		if err != nil {
+			continue
+		}
+		if result == nil || parsed.Compare(result) > 0 {
+			result = parsed
+		}
+	}
+	return result
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ma-vin/packages-action/config"
+	"github.com/ma-vin/packages-action/service/github_model"
+	"github.com/ma-vin/testutil-go"
+)
+
+func initContainerCandidateTest(tagsOne []string, tagsTwo []string, tagsThree []string) {
+	initCandidateTest()
+
+	candidatesConf.PackageType = config.CONTAINER
+	candidatesConf.ProtectedTags = []string{"latest"}
+
+	candidateVersionOne.Name = "sha256:1111"
+	candidateVersionOne.Metadata = github_model.Metadata{PackageType: github_model.CONTAINER, Container: github_model.Container{Tags: tagsOne}}
+	candidateVersionTwo.Name = "sha256:2222"
+	candidateVersionTwo.Metadata = github_model.Metadata{PackageType: github_model.CONTAINER, Container: github_model.Container{Tags: tagsTwo}}
+	candidateVersionThreee.Name = "sha256:3333"
+	candidateVersionThreee.Metadata = github_model.Metadata{PackageType: github_model.CONTAINER, Container: github_model.Container{Tags: tagsThree}}
+}
+
+func TestDetermineContainerCandidatesUntagged(t *testing.T) {
+	initContainerCandidateTest([]string{}, []string{"1.0.0"}, []string{"2.0.0", "latest"})
+
+	candidatesConf.DeleteUntagged = true
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(candidates, t, "candidates")
+	testutil.AssertEquals(1, len(*candidates), t, "len candidates")
+	testutil.AssertEquals(2, (*candidates)[0].Id, t, "id")
+	testutil.AssertEquals(VERSION_CANDIDATE, (*candidates)[0].Type, t, "type")
+}
+
+func TestDetermineContainerCandidatesMajor(t *testing.T) {
+	initContainerCandidateTest([]string{"v1.0.0"}, []string{"2.0.0", "2.0"}, []string{"3.0.0", "3"})
+
+	candidatesConf.NumberOfMajorVersionsToKeep = 2
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(candidates, t, "candidates")
+	testutil.AssertEquals(1, len(*candidates), t, "len candidates")
+	testutil.AssertEquals(2, (*candidates)[0].Id, t, "id")
+}
+
+func TestDetermineContainerCandidatesMajorIgnoresNonSemanticAndPrerelease(t *testing.T) {
+	initContainerCandidateTest([]string{"1.0.0"}, []string{"feature-abc"}, []string{"3.0.0-rc.1"})
+
+	candidatesConf.NumberOfMajorVersionsToKeep = 1
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(candidates, t, "candidates")
+	testutil.AssertEquals(0, len(*candidates), t, "len candidates")
+}
+
+func TestDetermineContainerCandidatesPrerelease(t *testing.T) {
+	initContainerCandidateTest([]string{"1.0.0"}, []string{"2.0.0-rc.1", "2.0.0-beta.1"}, []string{"2.0.0-rc.2", "2.0.0"})
+
+	candidatesConf.DeleteSnapshots = true
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(candidates, t, "candidates")
+	testutil.AssertEquals(1, len(*candidates), t, "len candidates")
+	testutil.AssertEquals(3, (*candidates)[0].Id, t, "id")
+}
+
+func TestDetermineContainerCandidatesProtectedTag(t *testing.T) {
+	initContainerCandidateTest([]string{"1.0.0", "latest"}, []string{"2.0.0", "stable"}, []string{"3.0.0"})
+
+	candidatesConf.NumberOfMajorVersionsToKeep = 1
+	candidatesConf.ProtectedTags = []string{"latest", "stable"}
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(candidates, t, "candidates")
+	testutil.AssertEquals(0, len(*candidates), t, "len candidates")
+}
+
+func TestDetermineContainerCandidatesTagPattern(t *testing.T) {
+	initContainerCandidateTest([]string{"pr-12", "pr-12-abc"}, []string{"pr-13", "1.0.0"}, []string{"pr-14", "latest"})
+
+	candidatesConf.TagPatternToDelete = "^pr-\\d+"
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(candidates, t, "candidates")
+	testutil.AssertEquals(1, len(*candidates), t, "len candidates")
+	testutil.AssertEquals(2, (*candidates)[0].Id, t, "id")
+}
+
+func TestDetermineContainerCandidatesInvalidTagPattern(t *testing.T) {
+	initContainerCandidateTest([]string{"pr-12"}, []string{"pr-13"}, []string{"pr-14"})
+
+	candidatesConf.TagPatternToDelete = "pr-("
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertNil(candidates, t, "candidates")
+}
+
+func TestDetermineContainerCandidatesVersionNameByTag(t *testing.T) {
+	initContainerCandidateTest([]string{"1.0.0"}, []string{"2.0.0"}, []string{"3.0.0"})
+
+	candidatesConf.VersionNameToDelete = "2.0.0"
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(candidates, t, "candidates")
+	testutil.AssertEquals(1, len(*candidates), t, "len candidates")
+	testutil.AssertEquals(3, (*candidates)[0].Id, t, "id")
+}
+
+func TestDetermineContainerCandidatesDockerDeletePackage(t *testing.T) {
+	initContainerCandidateTest(nil, nil, nil)
+
+	candidatesConf.EmptyPackagePolicy = config.EMPTY_PACKAGE_DELETE_PACKAGE
+	candidatesConf.PackageType = config.DOCKER
+	candidatesConf.DeleteUntagged = true
+	candidateVersionThreee.Metadata = github_model.Metadata{PackageType: github_model.DOCKER}
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(candidates, t, "candidates")
+	testutil.AssertEquals(1, len(*candidates), t, "len candidates")
+	testutil.AssertEquals(1, (*candidates)[0].Id, t, "id")
+	testutil.AssertEquals(PACKAGE_CANDIDATE, (*candidates)[0].Type, t, "type")
+}
@@ -1,37 +1,56 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/ma-vin/packages-action/config"
 	"github.com/ma-vin/typewriter/logger"
 )
 
-type DetermineCandidatesExecutor func(config *config.Config) (*[]Candidate, error)
-type GitHubDeleteVersionRestExecutor func(packageName string, versionId int, config *config.Config) error
-type GitHubDeletePackageRestExecutor func(packageName string, config *config.Config) error
+type DetermineCandidatesExecutor func(ctx context.Context, config *config.Config) (*[]PackageCandidates, error)
+type GitHubDeleteVersionRestExecutor func(ctx context.Context, packageName string, versionId int, config *config.Config) error
+type GitHubDeletePackageRestExecutor func(ctx context.Context, packageName string, config *config.Config) error
+
+// a candidate of a package which is to delete
+type deletionTask struct {
+	index       int
+	packageName string
+	candidate   Candidate
+}
+
+// the result of an executed deletion task. A cancelled task was not executed because the context was done before
+type deletionResult struct {
+	task      deletionTask
+	err       error
+	cancelled bool
+	// the deletion was started but interrupted by the cancellation of the run, so it is unknown whether it completed at GitHub
+	interrupted bool
+}
 
 var CandidatesExecutor DetermineCandidatesExecutor = initCandidatesExecutor()
 var DeleteVersionExecutor GitHubDeleteVersionRestExecutor = initDeleteVersionExecutor()
 var DeletePackageExecutor GitHubDeletePackageRestExecutor = initDeletePackageExecutor()
 
 func initCandidatesExecutor() DetermineCandidatesExecutor {
-	return func(config *config.Config) (*[]Candidate, error) {
-		return DetermineCandidates(config)
+	return func(ctx context.Context, config *config.Config) (*[]PackageCandidates, error) {
+		return DetermineAllCandidates(ctx, config)
 	}
 }
 
 func initDeleteVersionExecutor() GitHubDeleteVersionRestExecutor {
-	return func(packageName string, versionId int, config *config.Config) error {
-		return DeleteUserPackageVersion(packageName, versionId, config)
+	return func(ctx context.Context, packageName string, versionId int, config *config.Config) error {
+		return DeleteUserPackageVersion(ctx, packageName, versionId, config)
 	}
 }
 
 func initDeletePackageExecutor() GitHubDeletePackageRestExecutor {
-	return func(packageName string, config *config.Config) error {
-		return DeleteUserPackage(packageName, config)
+	return func(ctx context.Context, packageName string, config *config.Config) error {
+		return DeleteUserPackage(ctx, packageName, config)
 	}
 }
 
@@ -39,74 +58,251 @@ func InitAllDeletion() {
 	CandidatesExecutor = initCandidatesExecutor()
 	DeleteVersionExecutor = initDeleteVersionExecutor()
 	DeletePackageExecutor = initDeletePackageExecutor()
+	ReportExecutor = initReportExecutor()
+	OutputExecutor = initOutputExecutor()
+	PlanExecutor = initPlanExecutor()
 }
 
-// Deletes versions from Github with concurrency
-func DeleteVersions(config *config.Config) error {
-	candidates, err := CandidatesExecutor(config)
+// Deletes versions of all packages from Github with a limited number of concurrent deletions.
+// Afterwards the results are reported to the configured report file and step summary.
+// If the context is done, no further deletions are started and the remaining candidates are reported as cancelled
+func DeleteVersions(ctx context.Context, config *config.Config) error {
+	packageCandidates, err := CandidatesExecutor(ctx, config)
 	if err != nil {
 		return err
 	}
+	return newExecutorCleaner(config).planAndDeletePackageCandidates(ctx, packageCandidates)
+}
 
-	logCandidates(candidates)
+// Deletes versions of all packages of the configuration of the cleaner. See DeleteVersions
+func (c *Cleaner) DeleteVersions(ctx context.Context) error {
+	packageCandidates, err := c.DetermineCandidates(ctx)
+	if err != nil {
+		return err
+	}
+	return c.planAndDeletePackageCandidates(ctx, packageCandidates)
+}
 
-	count := len(*candidates)
-	if count == 0 {
-		return nil
+// Writes the plan of the candidates of all packages before they are deleted
+func (c *Cleaner) planAndDeletePackageCandidates(ctx context.Context, packageCandidates *[]PackageCandidates) error {
+	if err := c.planWriter(packageCandidates, c.configuration); err != nil {
+		return err
 	}
+	return c.deletePackageCandidates(ctx, packageCandidates)
+}
 
-	if config.DryRun {
-		logger.Information("Skip deletion because of dryRun")
-		return nil
+// Deletes the candidates of all packages, unless the deletion is to abort or it is a dry run. Afterwards the results are reported
+func (c *Cleaner) deletePackageCandidates(ctx context.Context, packageCandidates *[]PackageCandidates) error {
+	config := c.configuration
+	count := 0
+	for _, pc := range *packageCandidates {
+		logCandidates(&pc)
+		count += len(*pc.Candidates)
+	}
+	if len(*packageCandidates) > 1 {
+		logger.Informationf("%d candidates determined at %d packages", count, len(*packageCandidates))
 	}
 
-	channel := make(chan error, count)
-	var wg sync.WaitGroup
-	wg.Add(count)
+	tasks := createDeletionTasks(packageCandidates)
+	var results []deletionResult
+	abortErr := determineAbortion(packageCandidates, config)
+	if abortErr == nil && !config.DryRun && count > 0 {
+		abortErr = c.checkPermissions(ctx, packageCandidates)
+	}
+	switch {
+	case abortErr != nil:
+		logger.Errorf("Skip deletion: %v", abortErr)
+	case config.DryRun && count > 0:
+		logger.Information("Skip deletion because of dryRun")
+	case !config.DryRun:
+		results = c.executeDeletionTasks(ctx, tasks)
+	}
 
-	for _, c := range *candidates {
-		go deleteCandidate(&c, config, channel, &wg)
+	report := createReport(tasks, results, config)
+	addEmptyPackages(report, packageCandidates)
+	if abortErr != nil {
+		markReportAborted(report)
+	}
+	reportErr := c.reportWriter(report, config)
+	if outputErr := c.outputWriter(report, packageCandidates, config); outputErr != nil && reportErr == nil {
+		reportErr = outputErr
 	}
 
-	wg.Wait()
-	close(channel)
+	if abortErr != nil {
+		return abortErr
+	}
+	if cancelErr := checkCancellation(ctx, results); cancelErr != nil {
+		return cancelErr
+	}
 
 	withErrors := false
-	for err := range channel {
-		if err != nil {
+	for _, result := range results {
+		if result.err != nil {
 			withErrors = true
-			logger.Error(err.Error())
+			logger.Error(result.err.Error())
 		}
 	}
 	if withErrors {
 		return errors.New("delete execution with errors")
 	}
-	return nil
+	return reportErr
+}
+
+// Determines whether the deletion is to abort before any deletion: if the empty package policy of a package demands it
+// or if there are more versions to delete than allowed per run
+func determineAbortion(packageCandidates *[]PackageCandidates, config *config.Config) error {
+	if abortingPackages := determineAbortingPackages(packageCandidates); len(abortingPackages) > 0 {
+		return fmt.Errorf("deletion aborted: all versions of packages %s are to delete, which is not allowed by empty package policy %s",
+			strings.Join(abortingPackages, ", "), config.EmptyPackagePolicy)
+	}
+	return checkMaxVersionsToDelete(packageCandidates, config)
+}
+
+// Checks the permissions to delete the packages with candidates, if there is a permission checker
+func (c *Cleaner) checkPermissions(ctx context.Context, packageCandidates *[]PackageCandidates) error {
+	if c.PermissionChecker == nil {
+		return nil
+	}
+	return c.PermissionChecker.CheckPermissions(ctx, determinePackagesToDelete(packageCandidates), c.configuration)
+}
+
+// determines the names of the packages whose versions are all to delete and whose empty package policy demands to abort the deletion
+func determineAbortingPackages(packageCandidates *[]PackageCandidates) []string {
+	var res []string
+	for _, pc := range *packageCandidates {
+		if pc.EmptyPackagePolicy == config.EMPTY_PACKAGE_FAIL {
+			res = append(res, pc.PackageName)
+		}
+	}
+	return res
 }
 
-// executes the deletion for a candidate and confirms it to wainting group
-func deleteCandidate(candidate *Candidate, config *config.Config, channel chan error, wg *sync.WaitGroup) {
+// creates the tasks to delete for all candidates of all packages
+func createDeletionTasks(packageCandidates *[]PackageCandidates) []deletionTask {
+	var tasks []deletionTask
+	for _, pc := range *packageCandidates {
+		for _, c := range *pc.Candidates {
+			tasks = append(tasks, deletionTask{len(tasks), pc.PackageName, c})
+		}
+	}
+	return tasks
+}
+
+// executes the deletion tasks by a limited number of workers. The results are in the same order as the tasks
+func (c *Cleaner) executeDeletionTasks(ctx context.Context, tasks []deletionTask) []deletionResult {
+	if len(tasks) == 0 {
+		return []deletionResult{}
+	}
+
+	taskChannel := make(chan deletionTask)
+	channel := make(chan deletionResult, len(tasks))
+	var wg sync.WaitGroup
+
+	workers := min(max(c.configuration.MaxConcurrentDeletions, 1), len(tasks))
+	wg.Add(workers)
+	for range workers {
+		go c.deletionWorker(ctx, taskChannel, channel, &wg)
+	}
+
+	for _, task := range tasks {
+		taskChannel <- task
+	}
+	close(taskChannel)
+
+	wg.Wait()
+	close(channel)
+
+	results := make([]deletionResult, len(tasks))
+	for result := range channel {
+		results[result.task.index] = result
+	}
+	return results
+}
+
+// executes the deletion of tasks until there are no more and confirms it to wainting group afterwards. After each deletion the configured delay is awaited.
+// If the context is done, the remaining tasks are not executed but returned as cancelled. A deletion which fails because of the cancellation
+// is returned as interrupted
+func (c *Cleaner) deletionWorker(ctx context.Context, tasks <-chan deletionTask, channel chan<- deletionResult, wg *sync.WaitGroup) {
 	defer wg.Done()
 
+	config := c.configuration
+	for task := range tasks {
+		if ctx.Err() != nil {
+			channel <- deletionResult{task: task, err: context.Cause(ctx), cancelled: true}
+			continue
+		}
+		err := c.deleteCandidate(ctx, task.packageName, &task.candidate)
+		channel <- deletionResult{task: task, err: err, interrupted: isInterruptedBy(ctx, err)}
+		if config.DeletionDelay > 0 {
+			// a cancellation during the delay is recognized before the next task
+			_ = c.sleeper(ctx, time.Duration(config.DeletionDelay)*time.Millisecond)
+		}
+	}
+}
+
+// Checks whether an error of a deletion is caused by the cancellation of the context
+func isInterruptedBy(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() == nil {
+		return false
+	}
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Cause(ctx))
+}
+
+// Checks whether any deletion was cancelled or interrupted. In this case the completed and interrupted deletions are logged and an error with
+// the number of completed ones is returned
+func checkCancellation(ctx context.Context, results []deletionResult) error {
+	cancelled := 0
+	var completed []deletionResult
+	var interrupted []deletionResult
+	for _, result := range results {
+		switch {
+		case result.cancelled:
+			cancelled++
+		case result.interrupted:
+			interrupted = append(interrupted, result)
+		case result.err == nil:
+			completed = append(completed, result)
+		}
+	}
+	if cancelled == 0 && len(interrupted) == 0 {
+		return nil
+	}
+	logger.Errorf("deletion cancelled: %d of %d candidates deleted, %d interrupted with unknown outcome, %d not started", len(completed), len(results), len(interrupted), cancelled)
+	for _, result := range completed {
+		logger.Informationf("  deleted %s '%s' with id %d of package %s", getCandidateTypeText(&result.task.candidate.Type), result.task.candidate.Name,
+			result.task.candidate.Id, result.task.packageName)
+	}
+	for _, result := range interrupted {
+		logger.Warningf("  unknown whether %s '%s' with id %d of package %s was deleted", getCandidateTypeText(&result.task.candidate.Type), result.task.candidate.Name,
+			result.task.candidate.Id, result.task.packageName)
+	}
+	return fmt.Errorf("deletion cancelled after %d of %d deletions: %w", len(completed), len(results), context.Cause(ctx))
+}
+
+// executes the deletion for a candidate of a package
+func (c *Cleaner) deleteCandidate(ctx context.Context, packageName string, candidate *Candidate) error {
 	switch candidate.Type {
 	case VERSION_CANDIDATE:
-		channel <- DeleteVersionExecutor(config.PackageName, candidate.Id, config)
+		return c.Deleter.DeleteVersion(ctx, packageName, candidate.Id, c.configuration)
 	case PACKAGE_CANDIDATE:
-		channel <- DeletePackageExecutor(config.PackageName, config)
+		return c.Deleter.DeletePackage(ctx, packageName, c.configuration)
 	default:
-		channel <- fmt.Errorf("cannot delete candidate '%s' with id %d of unknown type", candidate.Name, candidate.Id)
+		return fmt.Errorf("cannot delete candidate '%s' with id %d of unknown type", candidate.Name, candidate.Id)
 	}
 }
 
-// logs the candidates which will be deleted
-func logCandidates(candidates *[]Candidate) {
-	if len(*candidates) == 0 {
-		logger.Information("no candidates determined")
+// logs the candidates of a package which will be deleted
+func logCandidates(packageCandidates *PackageCandidates) {
+	if len(*packageCandidates.Candidates) == 0 {
+		logger.Informationf("no candidates determined at package %s", packageCandidates.PackageName)
 		return
 	}
-	logger.Information("the following elements will be deleted")
-	for i, c := range *candidates {
+	logger.Informationf("the following elements of package %s will be deleted", packageCandidates.PackageName)
+	for i, c := range *packageCandidates.Candidates {
 		logger.Informationf("  %d. type: %s name: '%s' id: %d created: %s updated: %s description: '%s'", i+1, getCandidateTypeText(&c.Type), c.Name, c.Id, c.CreatedAt, c.UpdatedAt, c.Description)
+		for _, r := range c.MatchedRules {
+			logger.Informationf("     matched rule: %s - %s", r.Rule, r.Evidence)
+		}
 	}
 }
 
@@ -1,8 +1,14 @@
 package service
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/ma-vin/packages-action/config"
 	"github.com/ma-vin/testutil-go"
@@ -12,12 +18,18 @@ var deletionConf config.Config
 var deletionVersionCandidate Candidate
 var deletionPackageCandidate Candidate
 var deletionCandidates *[]Candidate
+var deletionEmptyPackagePolicy string
 var deletionCandidatesError error
 var deleteVersionError error
 var deletePackageError error
 var countGetCandidatesExecuted int
 var countDeleteVersionExecuted int
 var countDeletePackageExecuted int
+var tokenScopes []string
+var tokenScopesStatus int
+var deletedPackageNames chan string
+var writtenReport *Report
+var reportError error
 
 func initDeletionTest() {
 	deletionConf = config.Config{}
@@ -33,23 +45,49 @@ func initDeletionExecutor() {
 	countDeletePackageExecuted = 0
 
 	deletionCandidates = nil
+	deletionEmptyPackagePolicy = ""
+	deletedPackageNames = make(chan string, 10)
+	writtenReport = nil
+	reportError = nil
 
 	deletionCandidatesError = nil
 	deleteVersionError = nil
 	deletePackageError = nil
+	tokenScopes = nil
+	tokenScopesStatus = 0
 
-	CandidatesExecutor = func(config *config.Config) (*[]Candidate, error) {
+	CandidatesExecutor = func(ctx context.Context, config *config.Config) (*[]PackageCandidates, error) {
 		countGetCandidatesExecuted++
-		return deletionCandidates, deletionCandidatesError
+		if deletionCandidatesError != nil {
+			return nil, deletionCandidatesError
+		}
+		return &[]PackageCandidates{{config.PackageName, deletionCandidates, []string{}, deletionEmptyPackagePolicy, ""}}, nil
 	}
-	DeleteVersionExecutor = func(packageName string, versionId int, config *config.Config) error {
+	DeleteVersionExecutor = func(ctx context.Context, packageName string, versionId int, config *config.Config) error {
+		deletedPackageNames <- packageName
 		countDeleteVersionExecuted++
 		return deleteVersionError
 	}
-	DeletePackageExecutor = func(packageName string, config *config.Config) error {
+	DeletePackageExecutor = func(ctx context.Context, packageName string, config *config.Config) error {
 		countDeletePackageExecuted++
 		return deletePackageError
 	}
+	ClientRestExecutor = func(c *http.Client, req *http.Request) (*http.Response, error) {
+		body := packageJsonResponse
+		res := createResponse(&body, 200)
+		res.Header = http.Header{}
+		if tokenScopesStatus != 0 {
+			res.StatusCode = tokenScopesStatus
+		}
+		if tokenScopes != nil {
+			res.Header.Set("X-OAuth-Scopes", strings.Join(tokenScopes, ", "))
+		}
+		return res, nil
+	}
+	ReportExecutor = func(report *Report, config *config.Config) error {
+		writtenReport = report
+		return reportError
+	}
 }
 
 func TestDeleteVersionsSuccessfulVersionType(t *testing.T) {
@@ -57,7 +95,7 @@ func TestDeleteVersionsSuccessfulVersionType(t *testing.T) {
 
 	deletionCandidates = &[]Candidate{deletionVersionCandidate}
 
-	err := DeleteVersions(&deletionConf)
+	err := DeleteVersions(context.Background(), &deletionConf)
 	testutil.AssertNil(err, t, "err")
 	testutil.AssertEquals(1, countGetCandidatesExecuted, t, "get candidates executed")
 	testutil.AssertEquals(1, countDeleteVersionExecuted, t, "delete version executed")
@@ -69,7 +107,7 @@ func TestDeleteVersionsSuccessfulPackageType(t *testing.T) {
 
 	deletionCandidates = &[]Candidate{deletionPackageCandidate}
 
-	err := DeleteVersions(&deletionConf)
+	err := DeleteVersions(context.Background(), &deletionConf)
 	testutil.AssertNil(err, t, "err")
 	testutil.AssertEquals(1, countGetCandidatesExecuted, t, "get candidates executed")
 	testutil.AssertEquals(0, countDeleteVersionExecuted, t, "delete version executed")
@@ -81,7 +119,7 @@ func TestDeleteVersionsNoCandidates(t *testing.T) {
 
 	deletionCandidates = &[]Candidate{}
 
-	err := DeleteVersions(&deletionConf)
+	err := DeleteVersions(context.Background(), &deletionConf)
 	testutil.AssertNil(err, t, "err")
 	testutil.AssertEquals(1, countGetCandidatesExecuted, t, "get candidates executed")
 	testutil.AssertEquals(0, countDeleteVersionExecuted, t, "delete version executed")
@@ -93,7 +131,7 @@ func TestDeleteVersionsUnknwonType(t *testing.T) {
 
 	deletionCandidates = &[]Candidate{{Id: 3, Name: "Unknwon", Description: "Unknwon", CreatedAt: "2024-03-12T20:00:00Z", UpdatedAt: "2024-03-17T20:00:00Z", Type: PACKAGE_CANDIDATE + VERSION_CANDIDATE + 1}}
 
-	err := DeleteVersions(&deletionConf)
+	err := DeleteVersions(context.Background(), &deletionConf)
 	testutil.AssertNotNil(err, t, "err")
 	testutil.AssertEquals("delete execution with errors", err.Error(), t, "error message")
 	testutil.AssertEquals(1, countGetCandidatesExecuted, t, "get candidates executed")
@@ -106,7 +144,7 @@ func TestDeleteVersionsFailedGetCandidates(t *testing.T) {
 
 	deletionCandidatesError = errors.New("testError")
 
-	err := DeleteVersions(&deletionConf)
+	err := DeleteVersions(context.Background(), &deletionConf)
 	testutil.AssertNotNil(err, t, "err")
 	testutil.AssertEquals("testError", err.Error(), t, "error message")
 	testutil.AssertEquals(1, countGetCandidatesExecuted, t, "get candidates executed")
@@ -120,7 +158,7 @@ func TestDeleteVersionsFailedDeleteVersion(t *testing.T) {
 	deletionCandidates = &[]Candidate{deletionVersionCandidate}
 	deleteVersionError = errors.New("testError")
 
-	err := DeleteVersions(&deletionConf)
+	err := DeleteVersions(context.Background(), &deletionConf)
 	testutil.AssertNotNil(err, t, "err")
 	testutil.AssertEquals("delete execution with errors", err.Error(), t, "error message")
 	testutil.AssertEquals(1, countGetCandidatesExecuted, t, "get candidates executed")
@@ -134,7 +172,7 @@ func TestDeleteVersionsFailedDeletePackage(t *testing.T) {
 	deletionCandidates = &[]Candidate{deletionPackageCandidate}
 	deletePackageError = errors.New("testError")
 
-	err := DeleteVersions(&deletionConf)
+	err := DeleteVersions(context.Background(), &deletionConf)
 	testutil.AssertNotNil(err, t, "err")
 	testutil.AssertEquals("delete execution with errors", err.Error(), t, "error message")
 	testutil.AssertEquals(1, countGetCandidatesExecuted, t, "get candidates executed")
@@ -148,7 +186,7 @@ func TestDeleteVersionsSuccessfulDryRun(t *testing.T) {
 	deletionConf.DryRun = true
 	deletionCandidates = &[]Candidate{deletionVersionCandidate, deletionPackageCandidate}
 
-	err := DeleteVersions(&deletionConf)
+	err := DeleteVersions(context.Background(), &deletionConf)
 	testutil.AssertNil(err, t, "err")
 	testutil.AssertEquals(1, countGetCandidatesExecuted, t, "get candidates executed")
 	testutil.AssertEquals(0, countDeleteVersionExecuted, t, "delete version executed")
@@ -163,9 +201,340 @@ func TestDeleteVersionsSuccessfulMultiple(t *testing.T) {
 
 	deletionCandidates = &[]Candidate{deletionVersionCandidate, deletionVersionCandidateTwo, deletionPackageCandidate, deletionPackageCandidateTwo}
 
-	err := DeleteVersions(&deletionConf)
+	err := DeleteVersions(context.Background(), &deletionConf)
 	testutil.AssertNil(err, t, "err")
 	testutil.AssertEquals(1, countGetCandidatesExecuted, t, "get candidates executed")
 	testutil.AssertEquals(2, countDeleteVersionExecuted, t, "delete version executed")
 	testutil.AssertEquals(2, countDeletePackageExecuted, t, "delete package executed")
 }
+
+func TestDeleteVersionsSuccessfulMultiplePackages(t *testing.T) {
+	initDeletionTest()
+
+	deletionVersionCandidateTwo := Candidate{Id: 4, Name: "2.0.0", Description: "Second Version", CreatedAt: "2024-03-17T20:00:00Z", UpdatedAt: "2024-03-17T20:00:00Z", Type: VERSION_CANDIDATE}
+
+	CandidatesExecutor = func(ctx context.Context, config *config.Config) (*[]PackageCandidates, error) {
+		countGetCandidatesExecuted++
+		return &[]PackageCandidates{
+			{"DummyPackage", &[]Candidate{deletionVersionCandidate}, []string{}, "", ""},
+			{"OtherDummyPackage", &[]Candidate{deletionVersionCandidateTwo}, []string{}, "", ""},
+			{"EmptyDummyPackage", &[]Candidate{}, []string{}, "", ""}}, nil
+	}
+
+	err := DeleteVersions(context.Background(), &deletionConf)
+	close(deletedPackageNames)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertEquals(1, countGetCandidatesExecuted, t, "get candidates executed")
+	testutil.AssertEquals(2, countDeleteVersionExecuted, t, "delete version executed")
+	testutil.AssertEquals(0, countDeletePackageExecuted, t, "delete package executed")
+
+	packageNames := map[string]bool{}
+	for packageName := range deletedPackageNames {
+		packageNames[packageName] = true
+	}
+	testutil.AssertEquals(2, len(packageNames), t, "number of packages with deletions")
+	testutil.AssertTrue(packageNames["DummyPackage"], t, "deletion at DummyPackage")
+	testutil.AssertTrue(packageNames["OtherDummyPackage"], t, "deletion at OtherDummyPackage")
+}
+
+func TestDeleteVersionsLimitedConcurrency(t *testing.T) {
+	initDeletionTest()
+
+	deletionConf.MaxConcurrentDeletions = 2
+	deletionCandidates = &[]Candidate{}
+	for i := range 10 {
+		*deletionCandidates = append(*deletionCandidates, Candidate{Id: i, Name: "1.0.0", Type: VERSION_CANDIDATE})
+	}
+
+	var running atomic.Int32
+	var maxRunning atomic.Int32
+	var deleted atomic.Int32
+	DeleteVersionExecutor = func(ctx context.Context, packageName string, versionId int, config *config.Config) error {
+		current := running.Add(1)
+		for {
+			observed := maxRunning.Load()
+			if current <= observed || maxRunning.CompareAndSwap(observed, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		running.Add(-1)
+		deleted.Add(1)
+		return nil
+	}
+
+	err := DeleteVersions(context.Background(), &deletionConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertEquals(int32(10), deleted.Load(), t, "delete version executed")
+	testutil.AssertTrue(maxRunning.Load() <= 2, t, "at most two concurrent deletions")
+}
+
+func TestDeleteVersionsWithDelay(t *testing.T) {
+	initDeletionTest()
+
+	deletionConf.MaxConcurrentDeletions = 1
+	deletionConf.DeletionDelay = 250
+	deletionCandidates = &[]Candidate{deletionVersionCandidate, deletionPackageCandidate}
+
+	var sleeps []time.Duration
+	SleepExecutor = func(ctx context.Context, duration time.Duration) error {
+		sleeps = append(sleeps, duration)
+		return nil
+	}
+
+	err := DeleteVersions(context.Background(), &deletionConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertEquals(1, countDeleteVersionExecuted, t, "delete version executed")
+	testutil.AssertEquals(1, countDeletePackageExecuted, t, "delete package executed")
+	testutil.AssertEquals(2, len(sleeps), t, "number of delays")
+	testutil.AssertEquals(250*time.Millisecond, sleeps[0], t, "delay")
+}
+
+func TestDeleteVersionsReport(t *testing.T) {
+	initDeletionTest()
+
+	deletionConf.PackageName = "DummyPackage"
+	deletionCandidates = &[]Candidate{deletionVersionCandidate, deletionPackageCandidate}
+	deletePackageError = errors.New("testError")
+
+	err := DeleteVersions(context.Background(), &deletionConf)
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertNotNil(writtenReport, t, "report")
+	testutil.AssertEquals(2, len(writtenReport.Entries), t, "number of report entries")
+	testutil.AssertEquals("DummyPackage", writtenReport.Entries[0].Package, t, "package of first entry")
+	testutil.AssertEquals(RESULT_DELETED, writtenReport.Entries[0].Result, t, "result of first entry")
+	testutil.AssertEquals(RESULT_FAILED, writtenReport.Entries[1].Result, t, "result of second entry")
+	testutil.AssertEquals("testError", writtenReport.Entries[1].Error, t, "error of second entry")
+}
+
+func TestDeleteVersionsReportDryRun(t *testing.T) {
+	initDeletionTest()
+
+	deletionConf.DryRun = true
+	deletionCandidates = &[]Candidate{deletionVersionCandidate}
+
+	err := DeleteVersions(context.Background(), &deletionConf)
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(writtenReport, t, "report")
+	testutil.AssertTrue(writtenReport.DryRun, t, "dry run report")
+	testutil.AssertEquals(1, len(writtenReport.Entries), t, "number of report entries")
+	testutil.AssertEquals(RESULT_DRY_RUN, writtenReport.Entries[0].Result, t, "result of entry")
+}
+
+func TestDeleteVersionsReportError(t *testing.T) {
+	initDeletionTest()
+
+	deletionCandidates = &[]Candidate{deletionVersionCandidate}
+	reportError = errors.New("reportError")
+
+	err := DeleteVersions(context.Background(), &deletionConf)
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertEquals("reportError", err.Error(), t, "error message")
+	testutil.AssertEquals(1, countDeleteVersionExecuted, t, "delete version executed")
+}
+
+func TestDeleteVersionsEmptyPackageFail(t *testing.T) {
+	initDeletionTest()
+
+	deletionConf.PackageName = "DummyPackage"
+	deletionConf.EmptyPackagePolicy = config.EMPTY_PACKAGE_FAIL
+	deletionCandidates = &[]Candidate{deletionVersionCandidate}
+	deletionEmptyPackagePolicy = config.EMPTY_PACKAGE_FAIL
+
+	err := DeleteVersions(context.Background(), &deletionConf)
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertEquals(0, countDeleteVersionExecuted, t, "delete version executed")
+	testutil.AssertEquals(0, countDeletePackageExecuted, t, "delete package executed")
+	testutil.AssertNotNil(writtenReport, t, "report")
+	testutil.AssertEquals(1, len(writtenReport.Entries), t, "number of report entries")
+	testutil.AssertEquals(RESULT_ABORTED, writtenReport.Entries[0].Result, t, "result of entry")
+	testutil.AssertEquals(1, len(writtenReport.EmptyPackages), t, "number of empty packages")
+	testutil.AssertEquals("DummyPackage", writtenReport.EmptyPackages[0].Package, t, "empty package")
+	testutil.AssertEquals(config.EMPTY_PACKAGE_FAIL, writtenReport.EmptyPackages[0].Policy, t, "empty package policy")
+}
+
+func TestDeleteVersionsEmptyPackageKeepLatest(t *testing.T) {
+	initDeletionTest()
+
+	deletionConf.PackageName = "DummyPackage"
+	deletionCandidates = &[]Candidate{deletionVersionCandidate}
+	deletionEmptyPackagePolicy = config.EMPTY_PACKAGE_KEEP_LATEST
+
+	err := DeleteVersions(context.Background(), &deletionConf)
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertEquals(1, countDeleteVersionExecuted, t, "delete version executed")
+	testutil.AssertNotNil(writtenReport, t, "report")
+	testutil.AssertEquals(RESULT_DELETED, writtenReport.Entries[0].Result, t, "result of entry")
+	testutil.AssertEquals(1, len(writtenReport.EmptyPackages), t, "number of empty packages")
+	testutil.AssertEquals(config.EMPTY_PACKAGE_KEEP_LATEST, writtenReport.EmptyPackages[0].Policy, t, "empty package policy")
+}
+
+func TestDeleteVersionsMaxVersionsToDeleteExceeded(t *testing.T) {
+	initDeletionTest()
+
+	deletionConf.PackageName = "DummyPackage"
+	deletionConf.MaxVersionsToDelete = 1
+	deletionCandidates = &[]Candidate{deletionVersionCandidate, {Id: 3, Name: "1.1.0", Type: VERSION_CANDIDATE}}
+
+	err := DeleteVersions(context.Background(), &deletionConf)
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertEquals(0, countDeleteVersionExecuted, t, "delete version executed")
+	testutil.AssertNotNil(writtenReport, t, "report")
+	testutil.AssertEquals(RESULT_ABORTED, writtenReport.Entries[0].Result, t, "result of first entry")
+	testutil.AssertEquals(RESULT_ABORTED, writtenReport.Entries[1].Result, t, "result of second entry")
+}
+
+func TestDeleteVersionsMaxVersionsToDeleteExceededForced(t *testing.T) {
+	initDeletionTest()
+
+	deletionConf.PackageName = "DummyPackage"
+	deletionConf.MaxVersionsToDelete = 1
+	deletionConf.Force = true
+	deletionCandidates = &[]Candidate{deletionVersionCandidate, {Id: 3, Name: "1.1.0", Type: VERSION_CANDIDATE}}
+
+	err := DeleteVersions(context.Background(), &deletionConf)
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertEquals(2, countDeleteVersionExecuted, t, "delete version executed")
+}
+
+func TestDeleteVersionsPreflightMissingScope(t *testing.T) {
+	initDeletionTest()
+
+	deletionConf.PackageName = "DummyPackage"
+	deletionCandidates = &[]Candidate{deletionVersionCandidate, deletionPackageCandidate}
+	tokenScopes = []string{"read:packages", "write:packages"}
+
+	err := DeleteVersions(context.Background(), &deletionConf)
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertEquals("preflight check failed: the token lacks the scope delete:packages which is required to delete versions of package DummyPackage, granted scopes: read:packages, write:packages",
+		err.Error(), t, "error message")
+	testutil.AssertEquals(0, countDeleteVersionExecuted, t, "delete version executed")
+	testutil.AssertEquals(0, countDeletePackageExecuted, t, "delete package executed")
+	testutil.AssertNotNil(writtenReport, t, "report")
+	testutil.AssertEquals(RESULT_ABORTED, writtenReport.Entries[0].Result, t, "result of first entry")
+	testutil.AssertEquals(RESULT_ABORTED, writtenReport.Entries[1].Result, t, "result of second entry")
+}
+
+func TestDeleteVersionsPreflightWithScope(t *testing.T) {
+	initDeletionTest()
+
+	deletionConf.PackageName = "DummyPackage"
+	deletionCandidates = &[]Candidate{deletionVersionCandidate}
+	tokenScopes = []string{"read:packages", "delete:packages"}
+
+	err := DeleteVersions(context.Background(), &deletionConf)
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertEquals(1, countDeleteVersionExecuted, t, "delete version executed")
+}
+
+func TestDeleteVersionsPreflightNoAccess(t *testing.T) {
+	initDeletionTest()
+
+	deletionConf.PackageName = "DummyPackage"
+	deletionCandidates = &[]Candidate{deletionVersionCandidate}
+	tokenScopesStatus = 403
+
+	err := DeleteVersions(context.Background(), &deletionConf)
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertEquals("preflight check failed: the token can not access package DummyPackage: an error status code occured: 403 - Forbidden", err.Error(), t, "error message")
+	testutil.AssertEquals(0, countDeleteVersionExecuted, t, "delete version executed")
+}
+
+func TestDeleteVersionsPreflightDryRun(t *testing.T) {
+	initDeletionTest()
+
+	deletionConf.DryRun = true
+	deletionCandidates = &[]Candidate{deletionVersionCandidate}
+	tokenScopesStatus = 403
+
+	err := DeleteVersions(context.Background(), &deletionConf)
+	testutil.AssertNil(err, t, "err")
+}
+
+func TestDeleteVersionsCancelled(t *testing.T) {
+	initDeletionTest()
+
+	deletionConf.PackageName = "DummyPackage"
+	deletionConf.MaxConcurrentDeletions = 1
+	deletionCandidates = &[]Candidate{deletionVersionCandidate, {Id: 3, Name: "1.1.0", Type: VERSION_CANDIDATE}, {Id: 4, Name: "1.2.0", Type: VERSION_CANDIDATE}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	DeleteVersionExecutor = func(ctx context.Context, packageName string, versionId int, config *config.Config) error {
+		countDeleteVersionExecuted++
+		cancel()
+		return nil
+	}
+
+	err := DeleteVersions(ctx, &deletionConf)
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertTrue(errors.Is(err, context.Canceled), t, "cancelled error")
+	testutil.AssertEquals(1, countDeleteVersionExecuted, t, "delete version executed")
+	testutil.AssertNotNil(writtenReport, t, "report")
+	testutil.AssertEquals(RESULT_DELETED, writtenReport.Entries[0].Result, t, "result of first entry")
+	testutil.AssertEquals(RESULT_CANCELLED, writtenReport.Entries[1].Result, t, "result of second entry")
+	testutil.AssertEquals(RESULT_CANCELLED, writtenReport.Entries[2].Result, t, "result of third entry")
+}
+
+func TestDeleteVersionsInterrupted(t *testing.T) {
+	initDeletionTest()
+
+	deletionConf.PackageName = "DummyPackage"
+	deletionConf.MaxConcurrentDeletions = 1
+	deletionCandidates = &[]Candidate{deletionVersionCandidate, {Id: 3, Name: "1.1.0", Type: VERSION_CANDIDATE}, {Id: 4, Name: "1.2.0", Type: VERSION_CANDIDATE}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	DeleteVersionExecutor = func(ctx context.Context, packageName string, versionId int, config *config.Config) error {
+		countDeleteVersionExecuted++
+		if versionId == 2 {
+			return nil
+		}
+		// the request is in flight when the run is cancelled
+		cancel()
+		<-ctx.Done()
+		return fmt.Errorf("delete version %d: %w", versionId, ctx.Err())
+	}
+
+	err := DeleteVersions(ctx, &deletionConf)
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertTrue(errors.Is(err, context.Canceled), t, "cancelled error")
+	testutil.AssertEquals("deletion cancelled after 1 of 3 deletions: context canceled", err.Error(), t, "error message")
+	testutil.AssertEquals(2, countDeleteVersionExecuted, t, "delete version executed")
+	testutil.AssertNotNil(writtenReport, t, "report")
+	testutil.AssertEquals(RESULT_DELETED, writtenReport.Entries[0].Result, t, "result of first entry")
+	testutil.AssertEquals(RESULT_UNKNOWN, writtenReport.Entries[1].Result, t, "result of second entry")
+	testutil.AssertEquals("delete version 3: context canceled", writtenReport.Entries[1].Error, t, "error of second entry")
+	testutil.AssertEquals(RESULT_CANCELLED, writtenReport.Entries[2].Result, t, "result of third entry")
+}
+
+func TestDeleteVersionsFailedNotInterrupted(t *testing.T) {
+	initDeletionTest()
+
+	deletionConf.PackageName = "DummyPackage"
+	deletionCandidates = &[]Candidate{deletionVersionCandidate}
+	deleteVersionError = context.Canceled
+
+	err := DeleteVersions(context.Background(), &deletionConf)
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertEquals(RESULT_FAILED, writtenReport.Entries[0].Result, t, "result of entry")
+}
+
+func TestDeleteVersionsDeadlineExceededBefore(t *testing.T) {
+	initDeletionTest()
+
+	deletionConf.PackageName = "DummyPackage"
+	deletionCandidates = &[]Candidate{deletionVersionCandidate, deletionPackageCandidate}
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	err := DeleteVersions(ctx, &deletionConf)
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertTrue(errors.Is(err, context.DeadlineExceeded), t, "deadline error")
+	testutil.AssertEquals(0, countDeleteVersionExecuted, t, "delete version executed")
+	testutil.AssertEquals(0, countDeletePackageExecuted, t, "delete package executed")
+	testutil.AssertEquals(RESULT_CANCELLED, writtenReport.Entries[0].Result, t, "result of first entry")
+	testutil.AssertEquals(RESULT_CANCELLED, writtenReport.Entries[1].Result, t, "result of second entry")
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ma-vin/packages-action/config"
+	"github.com/ma-vin/typewriter/logger"
+)
+
+const app_url_part string = "app"
+const installations_url_part string = "installations"
+const access_tokens_url_part string = "access_tokens"
+
+// header of a JWT which is signed by RS256
+const appJwtHeader string = `{"alg":"RS256","typ":"JWT"}`
+
+// lifetime of the JWT of a GitHub App. GitHub accepts at most 10 minutes
+const appJwtLifetime time.Duration = 9 * time.Minute
+
+// the JWT of a GitHub App is issued in the past to allow a drift between the clocks
+const appJwtClockDrift time.Duration = time.Minute
+
+// an installation token is refreshed if it expires within this duration
+const installationTokenRefreshMargin time.Duration = 5 * time.Minute
+
+// claims of the JWT of a GitHub App
+type appJwtClaims struct {
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+	Issuer    string `json:"iss"`
+}
+
+// token of an installation of a GitHub App and its expiry
+type installationToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// installation tokens of GitHub Apps by rest api url, app and installation. The lock is held while a token is refreshed,
+// so concurrent requests wait for one refresh instead of requesting their own tokens
+type installationTokenCache struct {
+	mutex  sync.Mutex
+	tokens map[string]installationToken
+}
+
+var installationTokens = newInstallationTokenCache()
+
+func newInstallationTokenCache() *installationTokenCache {
+	return &installationTokenCache{tokens: map[string]installationToken{}}
+}
+
+// Determines the token to authorize rest calls: the installation token of the GitHub App if one is configured, otherwise the configured token
+func (client GitHubRestClient) determineAuthToken(ctx context.Context, configuration *config.Config) (string, error) {
+	if configuration.AppId == "" {
+		return configuration.GithubToken, nil
+	}
+	return client.installationTokens.get(ctx, client, configuration)
+}
+
+// Returns the installation token of the configured GitHub App. A new one is requested by the given client if there is none yet or if the known one expires soon
+func (cache *installationTokenCache) get(ctx context.Context, client GitHubRestClient, configuration *config.Config) (string, error) {
+	key := fmt.Sprintf("%s|%s|%d", configuration.GitHubRestUrl, configuration.AppId, configuration.AppInstallationId)
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if token, ok := cache.tokens[key]; ok && client.CurrentTime().Add(installationTokenRefreshMargin).Before(token.ExpiresAt) {
+		return token.Token, nil
+	}
+	token, err := client.requestInstallationToken(ctx, configuration)
+	if err != nil {
+		return "", err
+	}
+	cache.tokens[key] = *token
+	logger.Informationf("Installation token of GitHub App %s requested, valid until %s", configuration.AppId, token.ExpiresAt.Format(time.RFC3339))
+	return token.Token, nil
+}
+
+// calls GitHub rest api to create an installation token of the configured GitHub App. The request is authorized by the JWT of the app
+// /app/installations/{installation_id}/access_tokens
+func (client GitHubRestClient) requestInstallationToken(ctx context.Context, configuration *config.Config) (*installationToken, error) {
+	jwt, err := createAppJwt(configuration, client.CurrentTime())
+	if err != nil {
+		return nil, err
+	}
+	url := concatUrl(configuration.GitHubRestUrl, app_url_part, installations_url_part, strconv.Itoa(configuration.AppInstallationId), access_tokens_url_part)
+
+	response, err := client.executeRequest(ctx, http.MethodPost, url, configuration, nil, func() (string, error) { return jwt, nil })
+	if err != nil {
+		return nil, err
+	}
+
+	var token installationToken
+	if err = mapJsonResponse(response, &token, configuration); err != nil {
+		return nil, err
+	}
+	if token.Token == "" {
+		return nil, fmt.Errorf("there was no installation token created for GitHub App %s", configuration.AppId)
+	}
+	return &token, nil
+}
+
+// Creates the JWT of the configured GitHub App at a given point in time, which is signed by RS256 with the private key of the app
+func createAppJwt(configuration *config.Config, now time.Time) (string, error) {
+	key, err := config.ParseAppPrivateKey(configuration.AppPrivateKey)
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(appJwtClaims{IssuedAt: now.Add(-appJwtClockDrift).Unix(), ExpiresAt: now.Add(appJwtLifetime).Unix(), Issuer: configuration.AppId})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString([]byte(appJwtHeader)) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	hash := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(nil, key, crypto.SHA256, hash[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign the JWT of the GitHub App: %w", err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
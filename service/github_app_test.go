@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/ma-vin/packages-action/config"
+	"github.com/ma-vin/packages-action/service/github_model"
+	"github.com/ma-vin/packages-action/testutil"
+	testutilAssert "github.com/ma-vin/testutil-go"
+)
+
+var appConf config.Config
+var appKey *rsa.PrivateKey
+
+func initAppTest(t *testing.T) {
+	InitAllGitHubRest()
+	InitAllCandidates()
+
+	if appKey == nil {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		testutilAssert.AssertNil(err, t, "generate key")
+		appKey = key
+	}
+
+	mockServerUrl := testutil.CreateAndStartMock("DummyUser", config.MAVEN, "DummyPackage", &[]github_model.Version{}, &github_model.UserPackage{Id: 1, Name: "DummyPackage"})
+	testutil.AddAppInstallationToMock("12345", 42, &appKey.PublicKey, time.Hour)
+
+	appConf = config.Config{GitHubRestUrl: mockServerUrl, User: "DummyUser", PackageType: config.MAVEN, PackageName: "DummyPackage", Timeout: 3,
+		AppId: "12345", AppInstallationId: 42, AppPrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(appKey)}))}
+}
+
+func TestGetUserPackageWithAppInstallationToken(t *testing.T) {
+	initAppTest(t)
+	defer testutil.StopMock()
+
+	_, err := GetUserPackage(context.Background(), appConf.PackageName, &appConf)
+	testutilAssert.AssertNil(err, t, "first err")
+	_, err = GetUserPackageVersions(context.Background(), appConf.PackageName, &appConf)
+	testutilAssert.AssertNil(err, t, "second err")
+
+	authorizations := testutil.GetRequestAuthorizations()
+	testutilAssert.AssertEquals(1, testutil.CreateInstallationTokenCounter, t, "number of created installation tokens")
+	testutilAssert.AssertEquals(2, len(authorizations), t, "number of requests")
+	testutilAssert.AssertEquals("Bearer installation-token-1", authorizations[0], t, "first authorization")
+	testutilAssert.AssertEquals("Bearer installation-token-1", authorizations[1], t, "second authorization")
+}
+
+func TestGetUserPackageWithAppInstallationTokenRefreshed(t *testing.T) {
+	initAppTest(t)
+	defer testutil.StopMock()
+	defer InitAllCandidates()
+
+	_, err := GetUserPackage(context.Background(), appConf.PackageName, &appConf)
+	testutilAssert.AssertNil(err, t, "first err")
+
+	// the token of one hour expires within the refresh margin
+	CurrentTimeExecutor = func() time.Time {
+		return time.Now().Add(56 * time.Minute)
+	}
+	_, err = GetUserPackage(context.Background(), appConf.PackageName, &appConf)
+	testutilAssert.AssertNil(err, t, "second err")
+
+	authorizations := testutil.GetRequestAuthorizations()
+	testutilAssert.AssertEquals(2, testutil.CreateInstallationTokenCounter, t, "number of created installation tokens")
+	testutilAssert.AssertEquals("Bearer installation-token-1", authorizations[0], t, "first authorization")
+	testutilAssert.AssertEquals("Bearer installation-token-2", authorizations[1], t, "second authorization")
+}
+
+func TestGetUserPackageWithAppOfOtherKey(t *testing.T) {
+	initAppTest(t)
+	defer testutil.StopMock()
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	testutilAssert.AssertNil(err, t, "generate key")
+	appConf.AppPrivateKey = string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(otherKey)}))
+
+	userPackage, err := GetUserPackage(context.Background(), appConf.PackageName, &appConf)
+
+	testutilAssert.AssertNil(userPackage, t, "userPackage")
+	testutilAssert.AssertNotNil(err, t, "err")
+	testutilAssert.AssertEquals(0, testutil.CreateInstallationTokenCounter, t, "number of created installation tokens")
+	testutilAssert.AssertEquals(0, len(testutil.GetRequestAuthorizations()), t, "number of requests")
+}
+
+func TestDetermineAuthTokenWithoutApp(t *testing.T) {
+	token, err := NewGitHubRestClient().determineAuthToken(context.Background(), &config.Config{GithubToken: "abc"})
+
+	testutilAssert.AssertNil(err, t, "err")
+	testutilAssert.AssertEquals("abc", token, t, "token")
+}
+
+func TestGitHubRestClientWithOwnInstallationTokens(t *testing.T) {
+	initAppTest(t)
+	defer testutil.StopMock()
+
+	client := NewGitHubRestClient()
+	_, err := client.GetPackage(context.Background(), &appConf)
+	testutilAssert.AssertNil(err, t, "first err")
+
+	// the token of one hour expires within the refresh margin of the clock of the client, but not of the package level one
+	client.CurrentTime = func() time.Time {
+		return time.Now().Add(56 * time.Minute)
+	}
+	_, err = client.GetPackage(context.Background(), &appConf)
+	testutilAssert.AssertNil(err, t, "second err")
+	_, err = GetUserPackage(context.Background(), appConf.PackageName, &appConf)
+	testutilAssert.AssertNil(err, t, "third err")
+
+	authorizations := testutil.GetRequestAuthorizations()
+	testutilAssert.AssertEquals(3, testutil.CreateInstallationTokenCounter, t, "number of created installation tokens")
+	testutilAssert.AssertEquals("Bearer installation-token-1", authorizations[0], t, "first authorization")
+	testutilAssert.AssertEquals("Bearer installation-token-2", authorizations[1], t, "second authorization")
+	testutilAssert.AssertEquals("Bearer installation-token-3", authorizations[2], t, "third authorization")
+}
@@ -1,24 +1,37 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	neturl "net/url"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/ma-vin/packages-action/config"
 	"github.com/ma-vin/packages-action/service/github_model"
+	"github.com/ma-vin/typewriter/logger"
 )
 
 const gitHubModelVersion string = "2022-11-28"
 const gitHubModelJsonType string = "application/vnd.github+json"
 
 const users_url_part string = "users"
+const user_url_part string = "user"
+const orgs_url_part string = "orgs"
 const packages_url_part string = "packages"
 const versions_url_part string = "versions"
+const restore_url_part string = "restore"
+
+const per_page_parameter string = "per_page"
+const state_parameter string = "state"
+const deleted_state string = "deleted"
+const link_header string = "Link"
+const oauth_scopes_header string = "X-OAuth-Scopes"
+const next_page_relation string = `rel="next"`
 
 type queryParameter struct {
 	name  string
@@ -37,33 +50,35 @@ func initClientExector() ClientExecutor {
 
 func InitAllGitHubRest() {
 	ClientRestExecutor = initClientExector()
+	SleepExecutor = initSleepExecutor()
+	installationTokens = newInstallationTokenCache()
 }
 
-// calls GitHub rest api to get all packages of a certain type and user.
-// /users/{username}/packages
-func GetUserPackages(configuration *config.Config) (*[]github_model.UserPackage, error) {
-	url := concatUrl(configuration.GitHubRestUrl, users_url_part, configuration.User, packages_url_part)
-	response, err := get(url, configuration, []queryParameter{{name: "package_type", value: configuration.PackageType}})
-
-	if err != nil {
-		return nil, err
-	}
+// creates a rest client which uses the package level executors, clock and installation token cache
+func newExecutorRestClient() GitHubRestClient {
+	return GitHubRestClient{ClientExecutor: ClientRestExecutor, Sleeper: SleepExecutor, CurrentTime: CurrentTimeExecutor, installationTokens: installationTokens}
+}
 
-	var userPackages []github_model.UserPackage
-	err = mapJsonResponse(response, &userPackages, configuration)
+// calls GitHub rest api to get all packages of a certain type and user or organization.
+// /users/{username}/packages or /orgs/{org}/packages
+func GetUserPackages(ctx context.Context, configuration *config.Config) (*[]github_model.UserPackage, error) {
+	return newExecutorRestClient().getUserPackages(ctx, configuration)
+}
 
-	if err != nil {
-		return nil, err
-	}
+func (client GitHubRestClient) getUserPackages(ctx context.Context, configuration *config.Config) (*[]github_model.UserPackage, error) {
+	url := concatOwnerUrl(configuration, packages_url_part)
+	return getAllPages[github_model.UserPackage](ctx, client, url, configuration, []queryParameter{{name: "package_type", value: configuration.PackageType}})
+}
 
-	return &userPackages, nil
+// calls GitHub rest api to get a package of a certain type and user or organization.
+// /users/{username}/packages/{package_type}/{package_name} or /orgs/{org}/packages/{package_type}/{package_name}
+func GetUserPackage(ctx context.Context, packageName string, configuration *config.Config) (*github_model.UserPackage, error) {
+	return newExecutorRestClient().getUserPackage(ctx, packageName, configuration)
 }
 
-// calls GitHub rest api to get a package of a certain type and user.
-// users/{username}/packages/{package_type}/{package_name}
-func GetUserPackage(packageName string, configuration *config.Config) (*github_model.UserPackage, error) {
-	url := concatUrl(configuration.GitHubRestUrl, users_url_part, configuration.User, packages_url_part, configuration.PackageType, packageName)
-	response, err := get(url, configuration, nil)
+func (client GitHubRestClient) getUserPackage(ctx context.Context, packageName string, configuration *config.Config) (*github_model.UserPackage, error) {
+	url := concatOwnerUrl(configuration, packages_url_part, configuration.PackageType, packageName)
+	response, err := client.get(ctx, url, configuration, nil)
 
 	if err != nil {
 		return nil, err
@@ -78,12 +93,49 @@ func GetUserPackage(packageName string, configuration *config.Config) (*github_m
 	return &userPackage, nil
 }
 
-// calls GitHub rest api to get a package of a certain type and user
-// /users/{username}/packages/{package_type}/{package_name}
-func DeleteUserPackage(packageName string, configuration *config.Config) error {
-	url := concatUrl(configuration.GitHubRestUrl, users_url_part, configuration.User, packages_url_part, configuration.PackageType, packageName)
+// calls GitHub rest api to get a package of a certain type and user or organization and returns the OAuth scopes of the token.
+// The second result indicates whether the token has scopes at all: only classic personal access tokens and OAuth tokens have them
+// /users/{username}/packages/{package_type}/{package_name} or /orgs/{org}/packages/{package_type}/{package_name}
+func GetTokenScopes(ctx context.Context, packageName string, configuration *config.Config) ([]string, bool, error) {
+	return newExecutorRestClient().getTokenScopes(ctx, packageName, configuration)
+}
+
+func (client GitHubRestClient) getTokenScopes(ctx context.Context, packageName string, configuration *config.Config) ([]string, bool, error) {
+	url := concatOwnerUrl(configuration, packages_url_part, configuration.PackageType, packageName)
+	response, err := client.get(ctx, url, configuration, nil)
 
-	response, err := delete(url, configuration, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	if err = checkResponseStatusCode(response, configuration); err != nil {
+		return nil, false, err
+	}
+
+	values, ok := response.Header[http.CanonicalHeaderKey(oauth_scopes_header)]
+	if !ok {
+		return nil, false, nil
+	}
+	var scopes []string
+	for _, value := range values {
+		for _, scope := range strings.Split(value, ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	return scopes, true, nil
+}
+
+// calls GitHub rest api to delete a package of a certain type and user or organization
+// /users/{username}/packages/{package_type}/{package_name} or /orgs/{org}/packages/{package_type}/{package_name}
+func DeleteUserPackage(ctx context.Context, packageName string, configuration *config.Config) error {
+	return newExecutorRestClient().deleteUserPackage(ctx, packageName, configuration)
+}
+
+func (client GitHubRestClient) deleteUserPackage(ctx context.Context, packageName string, configuration *config.Config) error {
+	url := concatOwnerUrl(configuration, packages_url_part, configuration.PackageType, packageName)
+
+	response, err := client.delete(ctx, url, configuration, nil)
 
 	if err != nil {
 		return err
@@ -91,50 +143,99 @@ func DeleteUserPackage(packageName string, configuration *config.Config) error {
 	return checkResponseStatusCode(response, configuration)
 }
 
-// calls GitHub rest api to get all versions of a certain package, type and user.
-// /users/{username}/packages/{package_type}/{package_name}/versions
-func GetUserPackageVersions(packageName string, configuration *config.Config) (*[]github_model.Version, error) {
-	url := concatUrl(configuration.GitHubRestUrl, users_url_part, configuration.User, packages_url_part, configuration.PackageType, packageName, versions_url_part)
-	response, err := get(url, configuration, nil)
+// calls GitHub rest api to get all versions of a certain package, type and user or organization.
+// /users/{username}/packages/{package_type}/{package_name}/versions or /orgs/{org}/packages/{package_type}/{package_name}/versions
+func GetUserPackageVersions(ctx context.Context, packageName string, configuration *config.Config) (*[]github_model.Version, error) {
+	return newExecutorRestClient().getUserPackageVersions(ctx, packageName, configuration)
+}
+
+func (client GitHubRestClient) getUserPackageVersions(ctx context.Context, packageName string, configuration *config.Config) (*[]github_model.Version, error) {
+	url := concatOwnerUrl(configuration, packages_url_part, configuration.PackageType, packageName, versions_url_part)
+	return getAllPages[github_model.Version](ctx, client, url, configuration, nil)
+}
+
+// calls GitHub rest api to get a version of a certain package, type and user or organization.
+// /users/{username}/packages/{package_type}/{package_name}/versions/{package_version_id} or /orgs/{org}/packages/{package_type}/{package_name}/versions/{package_version_id}
+func GetUserPackageVersion(ctx context.Context, packageName string, versionId int, configuration *config.Config) (*github_model.Version, error) {
+	return newExecutorRestClient().getUserPackageVersion(ctx, packageName, versionId, configuration)
+}
+
+func (client GitHubRestClient) getUserPackageVersion(ctx context.Context, packageName string, versionId int, configuration *config.Config) (*github_model.Version, error) {
+	url := concatOwnerUrl(configuration, packages_url_part, configuration.PackageType, packageName, versions_url_part, strconv.Itoa(versionId))
+	response, err := client.get(ctx, url, configuration, nil)
 
 	if err != nil {
 		return nil, err
 	}
 
-	var versions []github_model.Version
-	err = mapJsonResponse(response, &versions, configuration)
+	var version github_model.Version
+	err = mapJsonResponse(response, &version, configuration)
 
 	if err != nil {
 		return nil, err
 	}
-	return &versions, nil
+	return &version, nil
+}
+
+// calls GitHub rest api to delete a version of a certain package, type and user or organization
+// /users/{username}/packages/{package_type}/{package_name}/versions/{package_version_id} or /orgs/{org}/packages/{package_type}/{package_name}/versions/{package_version_id}
+func DeleteUserPackageVersion(ctx context.Context, packageName string, versionId int, configuration *config.Config) error {
+	return newExecutorRestClient().deleteUserPackageVersion(ctx, packageName, versionId, configuration)
 }
 
-// calls GitHub rest api to get a version of a certain package, type and user.
-// /users/{username}/packages/{package_type}/{package_name}/versions/{package_version_id}
-func GetUserPackageVersion(packageName string, versionId int, configuration *config.Config) (*github_model.Version, error) {
-	url := concatUrl(configuration.GitHubRestUrl, users_url_part, configuration.User, packages_url_part, configuration.PackageType, packageName, versions_url_part, strconv.Itoa(versionId))
-	response, err := get(url, configuration, nil)
+func (client GitHubRestClient) deleteUserPackageVersion(ctx context.Context, packageName string, versionId int, configuration *config.Config) error {
+	url := concatOwnerUrl(configuration, packages_url_part, configuration.PackageType, packageName, versions_url_part, strconv.Itoa(versionId))
+
+	response, err := client.delete(ctx, url, configuration, nil)
 
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return checkResponseStatusCode(response, configuration)
+}
 
-	var version github_model.Version
-	err = mapJsonResponse(response, &version, configuration)
+// calls GitHub rest api to get all deleted versions of a certain package, type and user or organization. GitHub filters the state of the versions of a user
+// only at the packages of the authenticated user, so the packages of a user are requested as the ones of the user of the token.
+// /user/packages/{package_type}/{package_name}/versions?state=deleted or /orgs/{org}/packages/{package_type}/{package_name}/versions?state=deleted
+func GetDeletedUserPackageVersions(ctx context.Context, packageName string, configuration *config.Config) (*[]github_model.Version, error) {
+	return newExecutorRestClient().getDeletedUserPackageVersions(ctx, packageName, configuration)
+}
+
+func (client GitHubRestClient) getDeletedUserPackageVersions(ctx context.Context, packageName string, configuration *config.Config) (*[]github_model.Version, error) {
+	url := concatOwnerUrl(configuration, packages_url_part, configuration.PackageType, packageName, versions_url_part)
+	if configuration.Organization == "" {
+		url = concatUrl(configuration.GitHubRestUrl, user_url_part, packages_url_part, configuration.PackageType, packageName, versions_url_part)
+	}
+	return getAllPages[github_model.Version](ctx, client, url, configuration, []queryParameter{{name: state_parameter, value: deleted_state}})
+}
+
+// calls GitHub rest api to restore a deleted package of a certain type and user or organization
+// /users/{username}/packages/{package_type}/{package_name}/restore or /orgs/{org}/packages/{package_type}/{package_name}/restore
+func RestoreUserPackage(ctx context.Context, packageName string, configuration *config.Config) error {
+	return newExecutorRestClient().restoreUserPackage(ctx, packageName, configuration)
+}
+
+func (client GitHubRestClient) restoreUserPackage(ctx context.Context, packageName string, configuration *config.Config) error {
+	url := concatOwnerUrl(configuration, packages_url_part, configuration.PackageType, packageName, restore_url_part)
+
+	response, err := client.post(ctx, url, configuration, nil)
 
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return &version, nil
+	return checkResponseStatusCode(response, configuration)
 }
 
-// calls GitHub rest api to get a package of a certain type and user
-// /users/{username}/packages/{package_type}/{package_name}/versions/{package_version_id}
-func DeleteUserPackageVersion(packageName string, versionId int, configuration *config.Config) error {
-	url := concatUrl(configuration.GitHubRestUrl, users_url_part, configuration.User, packages_url_part, configuration.PackageType, packageName, versions_url_part, strconv.Itoa(versionId))
+// calls GitHub rest api to restore a deleted version of a certain package, type and user or organization
+// /users/{username}/packages/{package_type}/{package_name}/versions/{package_version_id}/restore or /orgs/{org}/packages/{package_type}/{package_name}/versions/{package_version_id}/restore
+func RestoreUserPackageVersion(ctx context.Context, packageName string, versionId int, configuration *config.Config) error {
+	return newExecutorRestClient().restoreUserPackageVersion(ctx, packageName, versionId, configuration)
+}
+
+func (client GitHubRestClient) restoreUserPackageVersion(ctx context.Context, packageName string, versionId int, configuration *config.Config) error {
+	url := concatOwnerUrl(configuration, packages_url_part, configuration.PackageType, packageName, versions_url_part, strconv.Itoa(versionId), restore_url_part)
 
-	response, err := delete(url, configuration, nil)
+	response, err := client.post(ctx, url, configuration, nil)
 
 	if err != nil {
 		return err
@@ -162,35 +263,136 @@ func mapJsonResponse(response *http.Response, target any, configuration *config.
 	return nil
 }
 
+// Executes get rest calls for all pages of a paginated resource and aggregates their json arrays.
+// The next page is determined by the link header with relation "next". It is only followed at the configured rest api, since the token is sent to it
+func getAllPages[T any](ctx context.Context, client GitHubRestClient, url string, configuration *config.Config, parameters []queryParameter) (*[]T, error) {
+	if configuration.PageSize > 0 {
+		parameters = append(parameters, queryParameter{name: per_page_parameter, value: strconv.Itoa(configuration.PageSize)})
+	}
+
+	var result []T
+	for url != "" {
+		response, err := client.get(ctx, url, configuration, parameters)
+		if err != nil {
+			return nil, err
+		}
+
+		var page []T
+		err = mapJsonResponse(response, &page, configuration)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, page...)
+
+		url = getNextPageUrl(response)
+		if err = checkSameOrigin(url, configuration.GitHubRestUrl); err != nil {
+			return nil, err
+		}
+		// the url of the next page contains already all query parameters
+		parameters = nil
+	}
+	return &result, nil
+}
+
+// determines the url of the next page from the link header of a response. If there is none, an empty string is returned
+func getNextPageUrl(response *http.Response) string {
+	for _, link := range strings.Split(response.Header.Get(link_header), ",") {
+		linkParts := strings.Split(link, ";")
+		if len(linkParts) < 2 {
+			continue
+		}
+		for _, param := range linkParts[1:] {
+			if strings.TrimSpace(param) == next_page_relation {
+				return strings.Trim(strings.TrimSpace(linkParts[0]), "<>")
+			}
+		}
+	}
+	return ""
+}
+
+// checks whether the url of a next page has the same scheme and host as the rest api. An empty url, without next page, is accepted
+func checkSameOrigin(url string, restUrl string) error {
+	if url == "" {
+		return nil
+	}
+	nextUrl, err := neturl.Parse(url)
+	if err != nil {
+		return fmt.Errorf("the url of the next page '%s' is not valid: %w", url, err)
+	}
+	apiUrl, err := neturl.Parse(restUrl)
+	if err != nil {
+		return fmt.Errorf("the url of the rest api '%s' is not valid: %w", restUrl, err)
+	}
+	if !strings.EqualFold(nextUrl.Scheme, apiUrl.Scheme) || !strings.EqualFold(nextUrl.Host, apiUrl.Host) {
+		return fmt.Errorf("the url of the next page '%s' is not at the rest api %s", url, restUrl)
+	}
+	return nil
+}
+
 // Executes a get rest call
-func get(url string, configuration *config.Config, parameters []queryParameter) (*http.Response, error) {
-	return executeRequestWithoutBody(http.MethodGet, url, configuration, parameters)
+func (client GitHubRestClient) get(ctx context.Context, url string, configuration *config.Config, parameters []queryParameter) (*http.Response, error) {
+	return client.executeRequestWithoutBody(ctx, http.MethodGet, url, configuration, parameters)
 }
 
 // Executes a delete rest call
-func delete(url string, configuration *config.Config, parameters []queryParameter) (*http.Response, error) {
-	return executeRequestWithoutBody(http.MethodDelete, url, configuration, parameters)
+func (client GitHubRestClient) delete(ctx context.Context, url string, configuration *config.Config, parameters []queryParameter) (*http.Response, error) {
+	return client.executeRequestWithoutBody(ctx, http.MethodDelete, url, configuration, parameters)
 }
 
-// creates the client, request, adds header elemets and url query parameters before sending. TLS is not configured explicitly since tls.Config uses TLS1.2 as MinVersion
-func executeRequestWithoutBody(operation string, url string, configuration *config.Config, parameters []queryParameter) (*http.Response, error) {
+// Executes a post rest call without body
+func (client GitHubRestClient) post(ctx context.Context, url string, configuration *config.Config, parameters []queryParameter) (*http.Response, error) {
+	return client.executeRequestWithoutBody(ctx, http.MethodPost, url, configuration, parameters)
+}
+
+// Executes a request which is authorized by the configured token or the installation token of the configured GitHub App
+func (client GitHubRestClient) executeRequestWithoutBody(ctx context.Context, operation string, url string, configuration *config.Config, parameters []queryParameter) (*http.Response, error) {
+	return client.executeRequest(ctx, operation, url, configuration, parameters, func() (string, error) {
+		return client.determineAuthToken(ctx, configuration)
+	})
+}
+
+// creates the client, request, adds header elemets and url query parameters before sending. TLS is not configured explicitly since tls.Config uses TLS1.2 as MinVersion.
+// Requests are retried with exponential backoff after rate limits, server or network errors up to the configured number of retries.
+// The token is determined at each attempt, so it may be refreshed while waiting. A cancelled context stops the request and the waiting before a retry
+func (client GitHubRestClient) executeRequest(ctx context.Context, operation string, url string, configuration *config.Config, parameters []queryParameter, tokenProvider func() (string, error)) (*http.Response, error) {
 	c := http.Client{Timeout: time.Duration(configuration.Timeout) * time.Second}
 
-	req, err := http.NewRequest(operation, url, nil)
-	if err != nil {
-		return nil, err
-	}
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, operation, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		token, err := tokenProvider()
+		if err != nil {
+			return nil, err
+		}
+
+		addHeader(req, token)
+		addUrlQueryParameters(req, &parameters)
 
-	addHeader(req, configuration)
-	addUrlQueryParameters(req, &parameters)
+		response, err := client.ClientExecutor(&c, req)
 
-	return ClientRestExecutor(&c, req)
+		wait, retry, reason := client.determineRetry(req, response, err, attempt, configuration)
+		if !retry {
+			if attempt > 0 {
+				logger.Informationf("%s '%s' finished after %d retries", operation, req.URL, attempt)
+			}
+			return response, err
+		}
+		if response != nil && response.Body != nil {
+			response.Body.Close()
+		}
+		logger.Warningf("Retry %d of %d for %s '%s' in %v because of %s", attempt+1, configuration.MaxRetries, operation, req.URL, wait, reason)
+		if err = client.Sleeper(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
 }
 
 // adds the default header elements for a call against GitHub rest api
-func addHeader(req *http.Request, configuration *config.Config) {
+func addHeader(req *http.Request, token string) {
 	req.Header.Add("Accept", gitHubModelJsonType)
-	req.Header.Add("Authorization", "Bearer "+configuration.GithubToken)
+	req.Header.Add("Authorization", "Bearer "+token)
 	req.Header.Add("X-GitHub-Api-Version", gitHubModelVersion)
 }
 
@@ -203,6 +405,20 @@ func addUrlQueryParameters(req *http.Request, parameters *[]queryParameter) {
 	req.URL.RawQuery = q.Encode()
 }
 
+// concats the url parts to the rest api url behind the owner of the packages: either an organization or a user
+func concatOwnerUrl(configuration *config.Config, urlParts ...string) string {
+	ownerUrlPart, ownerName := getOwnerUrlParts(configuration)
+	return concatUrl(append([]string{configuration.GitHubRestUrl, ownerUrlPart, ownerName}, urlParts...)...)
+}
+
+// determines the url part and name of the owner. An organization takes precedence over a user
+func getOwnerUrlParts(configuration *config.Config) (string, string) {
+	if configuration.Organization != "" {
+		return orgs_url_part, configuration.Organization
+	}
+	return users_url_part, configuration.User
+}
+
 func concatUrl(urlParts ...string) string {
 	var sb strings.Builder
 	for i, urlPart := range urlParts {
@@ -2,6 +2,7 @@ package service
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -54,6 +55,7 @@ const versionJsonResponse = `{
   }`
 
 var restConf = config.Config{GitHubRestUrl: "https://api.github.com", User: "DummyUser", PackageType: "maven", PackageName: "DummyPackage"}
+var restOrgConf = config.Config{GitHubRestUrl: "https://api.github.com", Organization: "DummyOrg", PackageType: "maven", PackageName: "DummyPackage"}
 
 type mockCloser struct {
 	mockIoErrorReader
@@ -105,6 +107,15 @@ func createDefaultVersionsArrayResponse() *http.Response {
 	return createResponse(&body, 200)
 }
 
+func createPageResponse(body string, nextUrl string) *http.Response {
+	res := createResponse(&body, 200)
+	res.Header = http.Header{}
+	if nextUrl != "" {
+		res.Header.Set("Link", fmt.Sprintf(`<%s>; rel="next", <https://api.github.com/last>; rel="last"`, nextUrl))
+	}
+	return res
+}
+
 func checkGetRequest(req *http.Request, url string, t *testing.T) {
 	checkRequest(req, url, http.MethodGet, t)
 }
@@ -113,6 +124,10 @@ func checkDeleteRequest(req *http.Request, url string, t *testing.T) {
 	checkRequest(req, url, http.MethodDelete, t)
 }
 
+func checkPostRequest(req *http.Request, url string, t *testing.T) {
+	checkRequest(req, url, http.MethodPost, t)
+}
+
 func checkRequest(req *http.Request, url string, method string, t *testing.T) {
 	testutil.AssertEquals(url, req.URL.String(), t, "request url")
 	testutil.AssertEquals(method, req.Method, t, "request method")
@@ -124,7 +139,7 @@ func TestGetUserPackageSuccessful(t *testing.T) {
 		return createDefaultPackageResponse(), nil
 	}
 
-	userPackage, err := GetUserPackage(restConf.PackageName, &restConf)
+	userPackage, err := GetUserPackage(context.Background(), restConf.PackageName, &restConf)
 
 	testutil.AssertNotNil(userPackage, t, "userPackage")
 	testutil.AssertEquals(123456, userPackage.Id, t, "package id")
@@ -132,12 +147,25 @@ func TestGetUserPackageSuccessful(t *testing.T) {
 	testutil.AssertNil(err, t, "err")
 }
 
+func TestGetUserPackageOrganizationSuccessful(t *testing.T) {
+	ClientRestExecutor = func(c *http.Client, req *http.Request) (*http.Response, error) {
+		checkGetRequest(req, "https://api.github.com/orgs/DummyOrg/packages/maven/DummyPackage", t)
+		return createDefaultPackageResponse(), nil
+	}
+
+	userPackage, err := GetUserPackage(context.Background(), restOrgConf.PackageName, &restOrgConf)
+
+	testutil.AssertNotNil(userPackage, t, "userPackage")
+	testutil.AssertEquals(123456, userPackage.Id, t, "package id")
+	testutil.AssertNil(err, t, "err")
+}
+
 func TestGetUserPackageWithError(t *testing.T) {
 	ClientRestExecutor = func(c *http.Client, req *http.Request) (*http.Response, error) {
 		return nil, errors.New("SomeTestError")
 	}
 
-	userPackage, err := GetUserPackage(restConf.PackageName, &restConf)
+	userPackage, err := GetUserPackage(context.Background(), restConf.PackageName, &restConf)
 
 	testutil.AssertNil(userPackage, t, "userPackage")
 	testutil.AssertNotNil(err, t, "err")
@@ -151,7 +179,7 @@ func TestGetUserPackageWithErrorHttpStatus(t *testing.T) {
 		return res, nil
 	}
 
-	userPackage, err := GetUserPackage(restConf.PackageName, &restConf)
+	userPackage, err := GetUserPackage(context.Background(), restConf.PackageName, &restConf)
 
 	testutil.AssertNil(userPackage, t, "userPackage")
 	testutil.AssertNotNil(err, t, "err")
@@ -164,7 +192,7 @@ func TestGetUserPackageWithBodyIoError(t *testing.T) {
 		return res, nil
 	}
 
-	userPackage, err := GetUserPackage(restConf.PackageName, &restConf)
+	userPackage, err := GetUserPackage(context.Background(), restConf.PackageName, &restConf)
 
 	testutil.AssertNil(userPackage, t, "userPackage")
 	testutil.AssertNotNil(err, t, "err")
@@ -178,20 +206,65 @@ func TestGetUserPackageWithInvalidJsonError(t *testing.T) {
 		return res, nil
 	}
 
-	userPackage, err := GetUserPackages(&restConf)
+	userPackage, err := GetUserPackages(context.Background(), &restConf)
 
 	testutil.AssertNil(userPackage, t, "userPackage")
 	testutil.AssertNotNil(err, t, "err")
 	testutil.AssertEquals("unexpected end of JSON input", err.Error(), t, "error message")
 }
 
+func TestGetTokenScopesSuccessful(t *testing.T) {
+	ClientRestExecutor = func(c *http.Client, req *http.Request) (*http.Response, error) {
+		checkGetRequest(req, "https://api.github.com/users/DummyUser/packages/maven/DummyPackage", t)
+		res := createDefaultPackageResponse()
+		res.Header = http.Header{}
+		res.Header.Set("X-OAuth-Scopes", "read:packages, delete:packages,repo")
+		return res, nil
+	}
+
+	scopes, hasScopes, err := GetTokenScopes(context.Background(), restConf.PackageName, &restConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertTrue(hasScopes, t, "has scopes")
+	testutil.AssertEquals(3, len(scopes), t, "number of scopes")
+	testutil.AssertEquals("read:packages", scopes[0], t, "first scope")
+	testutil.AssertEquals("delete:packages", scopes[1], t, "second scope")
+	testutil.AssertEquals("repo", scopes[2], t, "third scope")
+}
+
+func TestGetTokenScopesWithoutHeader(t *testing.T) {
+	ClientRestExecutor = func(c *http.Client, req *http.Request) (*http.Response, error) {
+		return createDefaultPackageResponse(), nil
+	}
+
+	scopes, hasScopes, err := GetTokenScopes(context.Background(), restConf.PackageName, &restConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertFalse(hasScopes, t, "has scopes")
+	testutil.AssertEquals(0, len(scopes), t, "number of scopes")
+}
+
+func TestGetTokenScopesWithErrorHttpStatus(t *testing.T) {
+	ClientRestExecutor = func(c *http.Client, req *http.Request) (*http.Response, error) {
+		res := createDefaultPackageResponse()
+		res.StatusCode = 404
+		return res, nil
+	}
+
+	_, hasScopes, err := GetTokenScopes(context.Background(), restConf.PackageName, &restConf)
+
+	testutil.AssertFalse(hasScopes, t, "has scopes")
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertEquals("an error status code occured: 404 - Not Found", err.Error(), t, "error message")
+}
+
 func TestGetUserPackagesArraySuccessful(t *testing.T) {
 	ClientRestExecutor = func(c *http.Client, req *http.Request) (*http.Response, error) {
 		checkGetRequest(req, "https://api.github.com/users/DummyUser/packages?package_type=maven", t)
 		return createDefaultPackagesArrayResponse(), nil
 	}
 
-	userPackages, err := GetUserPackages(&restConf)
+	userPackages, err := GetUserPackages(context.Background(), &restConf)
 
 	testutil.AssertNotNil(userPackages, t, "userPackages")
 
@@ -201,12 +274,76 @@ func TestGetUserPackagesArraySuccessful(t *testing.T) {
 	testutil.AssertNil(err, t, "err")
 }
 
+func TestGetUserPackagesArrayOrganizationSuccessful(t *testing.T) {
+	ClientRestExecutor = func(c *http.Client, req *http.Request) (*http.Response, error) {
+		checkGetRequest(req, "https://api.github.com/orgs/DummyOrg/packages?package_type=maven", t)
+		return createDefaultPackagesArrayResponse(), nil
+	}
+
+	userPackages, err := GetUserPackages(context.Background(), &restOrgConf)
+
+	testutil.AssertNotNil(userPackages, t, "userPackages")
+	testutil.AssertEquals(1, len(*userPackages), t, "package id")
+	testutil.AssertNil(err, t, "err")
+}
+
+func TestGetUserPackagesArrayMultiplePages(t *testing.T) {
+	pagedConf := restConf
+	pagedConf.PageSize = 1
+	callCount := 0
+	ClientRestExecutor = func(c *http.Client, req *http.Request) (*http.Response, error) {
+		callCount++
+		if callCount == 1 {
+			checkGetRequest(req, "https://api.github.com/users/DummyUser/packages?package_type=maven&per_page=1", t)
+			return createPageResponse(fmt.Sprintf("[%s]", packageJsonResponse), "https://api.github.com/users/DummyUser/packages?package_type=maven&page=2&per_page=1"), nil
+		}
+		checkGetRequest(req, "https://api.github.com/users/DummyUser/packages?package_type=maven&page=2&per_page=1", t)
+		return createPageResponse(fmt.Sprintf("[%s]", packageJsonResponse), ""), nil
+	}
+
+	userPackages, err := GetUserPackages(context.Background(), &pagedConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(userPackages, t, "userPackages")
+	testutil.AssertEquals(2, callCount, t, "number of calls")
+	testutil.AssertEquals(2, len(*userPackages), t, "number of packages")
+}
+
+func TestGetUserPackagesNextPageOfOtherHost(t *testing.T) {
+	callCount := 0
+	ClientRestExecutor = func(c *http.Client, req *http.Request) (*http.Response, error) {
+		callCount++
+		return createPageResponse(fmt.Sprintf("[%s]", packageJsonResponse), "https://example.com/users/DummyUser/packages?page=2"), nil
+	}
+
+	userPackages, err := GetUserPackages(context.Background(), &restConf)
+
+	testutil.AssertNil(userPackages, t, "userPackages")
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertEquals("the url of the next page 'https://example.com/users/DummyUser/packages?page=2' is not at the rest api https://api.github.com", err.Error(), t, "error message")
+	testutil.AssertEquals(1, callCount, t, "number of calls")
+}
+
+func TestGetUserPackagesNextPageOfOtherScheme(t *testing.T) {
+	callCount := 0
+	ClientRestExecutor = func(c *http.Client, req *http.Request) (*http.Response, error) {
+		callCount++
+		return createPageResponse(fmt.Sprintf("[%s]", packageJsonResponse), "http://api.github.com/users/DummyUser/packages?page=2"), nil
+	}
+
+	userPackages, err := GetUserPackages(context.Background(), &restConf)
+
+	testutil.AssertNil(userPackages, t, "userPackages")
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertEquals(1, callCount, t, "number of calls")
+}
+
 func TestGetUserPackagesArrayWithError(t *testing.T) {
 	ClientRestExecutor = func(c *http.Client, req *http.Request) (*http.Response, error) {
 		return nil, errors.New("SomeTestError")
 	}
 
-	userPackages, err := GetUserPackages(&restConf)
+	userPackages, err := GetUserPackages(context.Background(), &restConf)
 
 	testutil.AssertNil(userPackages, t, "userPackages")
 	testutil.AssertNotNil(err, t, "err")
@@ -220,7 +357,7 @@ func TestGetUserPackagesArrayWithErrorHttpStatus(t *testing.T) {
 		return res, nil
 	}
 
-	userPackages, err := GetUserPackages(&restConf)
+	userPackages, err := GetUserPackages(context.Background(), &restConf)
 
 	testutil.AssertNil(userPackages, t, "userPackages")
 	testutil.AssertNotNil(err, t, "err")
@@ -233,7 +370,7 @@ func TestGetUserPackagesArrayWithBodyIoError(t *testing.T) {
 		return res, nil
 	}
 
-	userPackages, err := GetUserPackages(&restConf)
+	userPackages, err := GetUserPackages(context.Background(), &restConf)
 
 	testutil.AssertNil(userPackages, t, "userPackages")
 	testutil.AssertNotNil(err, t, "err")
@@ -247,7 +384,7 @@ func TestGetUserPackagesArrayWithInvalidJsonError(t *testing.T) {
 		return res, nil
 	}
 
-	userPackages, err := GetUserPackages(&restConf)
+	userPackages, err := GetUserPackages(context.Background(), &restConf)
 
 	testutil.AssertNil(userPackages, t, "userPackages")
 	testutil.AssertNotNil(err, t, "err")
@@ -260,7 +397,7 @@ func TestGetGetUserPackageVersionSuccessful(t *testing.T) {
 		return createDefaultVersionResponse(), nil
 	}
 
-	version, err := GetUserPackageVersion(restConf.PackageName, 123456, &restConf)
+	version, err := GetUserPackageVersion(context.Background(), restConf.PackageName, 123456, &restConf)
 
 	testutil.AssertNotNil(version, t, "version")
 	testutil.AssertEquals(123456, version.Id, t, "package id")
@@ -268,12 +405,25 @@ func TestGetGetUserPackageVersionSuccessful(t *testing.T) {
 	testutil.AssertNil(err, t, "err")
 }
 
+func TestGetGetUserPackageVersionOrganizationSuccessful(t *testing.T) {
+	ClientRestExecutor = func(c *http.Client, req *http.Request) (*http.Response, error) {
+		checkGetRequest(req, "https://api.github.com/orgs/DummyOrg/packages/maven/DummyPackage/versions/123456", t)
+		return createDefaultVersionResponse(), nil
+	}
+
+	version, err := GetUserPackageVersion(context.Background(), restOrgConf.PackageName, 123456, &restOrgConf)
+
+	testutil.AssertNotNil(version, t, "version")
+	testutil.AssertEquals(123456, version.Id, t, "package id")
+	testutil.AssertNil(err, t, "err")
+}
+
 func TestGetGetUserPackageVersionWithError(t *testing.T) {
 	ClientRestExecutor = func(c *http.Client, req *http.Request) (*http.Response, error) {
 		return nil, errors.New("SomeTestError")
 	}
 
-	version, err := GetUserPackageVersion(restConf.PackageName, 123456, &restConf)
+	version, err := GetUserPackageVersion(context.Background(), restConf.PackageName, 123456, &restConf)
 
 	testutil.AssertNil(version, t, "version")
 	testutil.AssertNotNil(err, t, "err")
@@ -287,7 +437,7 @@ func TestGetGetUserPackageVersionWithErrorHttpStatus(t *testing.T) {
 		return res, nil
 	}
 
-	version, err := GetUserPackageVersion(restConf.PackageName, 123456, &restConf)
+	version, err := GetUserPackageVersion(context.Background(), restConf.PackageName, 123456, &restConf)
 
 	testutil.AssertNil(version, t, "version")
 	testutil.AssertNotNil(err, t, "err")
@@ -300,7 +450,7 @@ func TestGetGetUserPackageVersionWithBodyIoError(t *testing.T) {
 		return res, nil
 	}
 
-	version, err := GetUserPackageVersion(restConf.PackageName, 123456, &restConf)
+	version, err := GetUserPackageVersion(context.Background(), restConf.PackageName, 123456, &restConf)
 
 	testutil.AssertNil(version, t, "version")
 	testutil.AssertNotNil(err, t, "err")
@@ -314,7 +464,7 @@ func TestGetGetUserPackageVersionWithInvalidJsonError(t *testing.T) {
 		return res, nil
 	}
 
-	version, err := GetUserPackageVersion(restConf.PackageName, 123456, &restConf)
+	version, err := GetUserPackageVersion(context.Background(), restConf.PackageName, 123456, &restConf)
 
 	testutil.AssertNil(version, t, "version")
 	testutil.AssertNotNil(err, t, "err")
@@ -327,7 +477,7 @@ func TestGetGetUserPackageVersionsArraySuccessful(t *testing.T) {
 		return createDefaultVersionsArrayResponse(), nil
 	}
 
-	versions, err := GetUserPackageVersions(restConf.PackageName, &restConf)
+	versions, err := GetUserPackageVersions(context.Background(), restConf.PackageName, &restConf)
 
 	testutil.AssertNotNil(versions, t, "versions")
 	testutil.AssertEquals(1, len(*versions), t, "package id")
@@ -336,12 +486,74 @@ func TestGetGetUserPackageVersionsArraySuccessful(t *testing.T) {
 	testutil.AssertNil(err, t, "err")
 }
 
+func TestGetGetUserPackageVersionsArrayOrganizationSuccessful(t *testing.T) {
+	ClientRestExecutor = func(c *http.Client, req *http.Request) (*http.Response, error) {
+		checkGetRequest(req, "https://api.github.com/orgs/DummyOrg/packages/maven/DummyPackage/versions", t)
+		return createDefaultVersionsArrayResponse(), nil
+	}
+
+	versions, err := GetUserPackageVersions(context.Background(), restOrgConf.PackageName, &restOrgConf)
+
+	testutil.AssertNotNil(versions, t, "versions")
+	testutil.AssertEquals(1, len(*versions), t, "package id")
+	testutil.AssertNil(err, t, "err")
+}
+
+func TestGetGetUserPackageVersionsArrayMultiplePages(t *testing.T) {
+	pagedConf := restConf
+	pagedConf.PageSize = 1
+	callCount := 0
+	ClientRestExecutor = func(c *http.Client, req *http.Request) (*http.Response, error) {
+		callCount++
+		switch callCount {
+		case 1:
+			checkGetRequest(req, "https://api.github.com/users/DummyUser/packages/maven/DummyPackage/versions?per_page=1", t)
+			return createPageResponse(fmt.Sprintf("[%s]", versionJsonResponse), "https://api.github.com/users/DummyUser/packages/maven/DummyPackage/versions?page=2&per_page=1"), nil
+		case 2:
+			checkGetRequest(req, "https://api.github.com/users/DummyUser/packages/maven/DummyPackage/versions?page=2&per_page=1", t)
+			return createPageResponse(fmt.Sprintf("[%s]", versionJsonResponse), "https://api.github.com/users/DummyUser/packages/maven/DummyPackage/versions?page=3&per_page=1"), nil
+		default:
+			checkGetRequest(req, "https://api.github.com/users/DummyUser/packages/maven/DummyPackage/versions?page=3&per_page=1", t)
+			return createPageResponse(fmt.Sprintf("[%s]", versionJsonResponse), ""), nil
+		}
+	}
+
+	versions, err := GetUserPackageVersions(context.Background(), pagedConf.PackageName, &pagedConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(versions, t, "versions")
+	testutil.AssertEquals(3, callCount, t, "number of calls")
+	testutil.AssertEquals(3, len(*versions), t, "number of versions")
+}
+
+func TestGetGetUserPackageVersionsArrayMultiplePagesWithErrorHttpStatus(t *testing.T) {
+	pagedConf := restConf
+	pagedConf.PageSize = 1
+	callCount := 0
+	ClientRestExecutor = func(c *http.Client, req *http.Request) (*http.Response, error) {
+		callCount++
+		if callCount == 1 {
+			return createPageResponse(fmt.Sprintf("[%s]", versionJsonResponse), "https://api.github.com/users/DummyUser/packages/maven/DummyPackage/versions?page=2&per_page=1"), nil
+		}
+		res := createPageResponse("", "")
+		res.StatusCode = 400
+		return res, nil
+	}
+
+	versions, err := GetUserPackageVersions(context.Background(), pagedConf.PackageName, &pagedConf)
+
+	testutil.AssertNil(versions, t, "versions")
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertEquals(2, callCount, t, "number of calls")
+	testutil.AssertEquals("an error status code occured: 400 - Bad Request", err.Error(), t, "error message")
+}
+
 func TestGetGetUserPackageVersionsArrayWithError(t *testing.T) {
 	ClientRestExecutor = func(c *http.Client, req *http.Request) (*http.Response, error) {
 		return nil, errors.New("SomeTestError")
 	}
 
-	versions, err := GetUserPackageVersions(restConf.PackageName, &restConf)
+	versions, err := GetUserPackageVersions(context.Background(), restConf.PackageName, &restConf)
 
 	testutil.AssertNil(versions, t, "versions")
 	testutil.AssertNotNil(err, t, "err")
@@ -355,7 +567,7 @@ func TestGetGetUserPackageVersionsArrayWithErrorHttpStatus(t *testing.T) {
 		return res, nil
 	}
 
-	versions, err := GetUserPackageVersions(restConf.PackageName, &restConf)
+	versions, err := GetUserPackageVersions(context.Background(), restConf.PackageName, &restConf)
 
 	testutil.AssertNil(versions, t, "versions")
 	testutil.AssertNotNil(err, t, "err")
@@ -368,7 +580,7 @@ func TestGetGetUserPackageVersionsArrayWithBodyIoError(t *testing.T) {
 		return res, nil
 	}
 
-	versions, err := GetUserPackageVersions(restConf.PackageName, &restConf)
+	versions, err := GetUserPackageVersions(context.Background(), restConf.PackageName, &restConf)
 
 	testutil.AssertNil(versions, t, "versions")
 	testutil.AssertNotNil(err, t, "err")
@@ -382,7 +594,7 @@ func TestGetGetUserPackageVersionsArrayWithInvalidJsonError(t *testing.T) {
 		return res, nil
 	}
 
-	versions, err := GetUserPackageVersions(restConf.PackageName, &restConf)
+	versions, err := GetUserPackageVersions(context.Background(), restConf.PackageName, &restConf)
 
 	testutil.AssertNil(versions, t, "versions")
 	testutil.AssertNotNil(err, t, "err")
@@ -397,7 +609,20 @@ func TestDeleteUserPackageSuccessful(t *testing.T) {
 		return res, nil
 	}
 
-	err := DeleteUserPackage(restConf.PackageName, &restConf)
+	err := DeleteUserPackage(context.Background(), restConf.PackageName, &restConf)
+
+	testutil.AssertNil(err, t, "err")
+}
+
+func TestDeleteUserPackageOrganizationSuccessful(t *testing.T) {
+	ClientRestExecutor = func(c *http.Client, req *http.Request) (*http.Response, error) {
+		checkDeleteRequest(req, "https://api.github.com/orgs/DummyOrg/packages/maven/DummyPackage", t)
+		var body = ""
+		res := createResponse(&body, 200)
+		return res, nil
+	}
+
+	err := DeleteUserPackage(context.Background(), restOrgConf.PackageName, &restOrgConf)
 
 	testutil.AssertNil(err, t, "err")
 }
@@ -407,7 +632,7 @@ func TestDeleteUserPackageWithError(t *testing.T) {
 		return nil, errors.New("SomeTestError")
 	}
 
-	err := DeleteUserPackage(restConf.PackageName, &restConf)
+	err := DeleteUserPackage(context.Background(), restConf.PackageName, &restConf)
 
 	testutil.AssertNotNil(err, t, "err")
 	testutil.AssertEquals("SomeTestError", err.Error(), t, "error message")
@@ -420,7 +645,7 @@ func TestDeleteUserPackageWithErrorHttpStatus(t *testing.T) {
 		return res, nil
 	}
 
-	err := DeleteUserPackage(restConf.PackageName, &restConf)
+	err := DeleteUserPackage(context.Background(), restConf.PackageName, &restConf)
 
 	testutil.AssertNotNil(err, t, "err")
 	testutil.AssertEquals("an error status code occured: 400 - Bad Request", err.Error(), t, "error message")
@@ -434,7 +659,20 @@ func TestDeleteUserPackageVersionSuccessful(t *testing.T) {
 		return res, nil
 	}
 
-	err := DeleteUserPackageVersion(restConf.PackageName, 1, &restConf)
+	err := DeleteUserPackageVersion(context.Background(), restConf.PackageName, 1, &restConf)
+
+	testutil.AssertNil(err, t, "err")
+}
+
+func TestDeleteUserPackageVersionOrganizationSuccessful(t *testing.T) {
+	ClientRestExecutor = func(c *http.Client, req *http.Request) (*http.Response, error) {
+		checkDeleteRequest(req, "https://api.github.com/orgs/DummyOrg/packages/maven/DummyPackage/versions/1", t)
+		var body = ""
+		res := createResponse(&body, 200)
+		return res, nil
+	}
+
+	err := DeleteUserPackageVersion(context.Background(), restOrgConf.PackageName, 1, &restOrgConf)
 
 	testutil.AssertNil(err, t, "err")
 }
@@ -444,7 +682,7 @@ func TestDeleteUserPackageVersionWithError(t *testing.T) {
 		return nil, errors.New("SomeTestError")
 	}
 
-	err := DeleteUserPackageVersion(restConf.PackageName, 1, &restConf)
+	err := DeleteUserPackageVersion(context.Background(), restConf.PackageName, 1, &restConf)
 
 	testutil.AssertNotNil(err, t, "err")
 	testutil.AssertEquals("SomeTestError", err.Error(), t, "error message")
@@ -457,8 +695,85 @@ func TestDeleteUserPackageVersionWithErrorHttpStatus(t *testing.T) {
 		return res, nil
 	}
 
-	err := DeleteUserPackageVersion(restConf.PackageName, 1, &restConf)
+	err := DeleteUserPackageVersion(context.Background(), restConf.PackageName, 1, &restConf)
 
 	testutil.AssertNotNil(err, t, "err")
 	testutil.AssertEquals("an error status code occured: 400 - Bad Request", err.Error(), t, "error message")
 }
+
+func TestGetDeletedUserPackageVersionsSuccessful(t *testing.T) {
+	ClientRestExecutor = func(c *http.Client, req *http.Request) (*http.Response, error) {
+		checkGetRequest(req, "https://api.github.com/user/packages/maven/DummyPackage/versions?state=deleted", t)
+		return createDefaultVersionsArrayResponse(), nil
+	}
+
+	versions, err := GetDeletedUserPackageVersions(context.Background(), restConf.PackageName, &restConf)
+
+	testutil.AssertNotNil(versions, t, "versions")
+	testutil.AssertEquals(1, len(*versions), t, "package id")
+	testutil.AssertEquals(123456, (*versions)[0].Id, t, "package id")
+	testutil.AssertNil(err, t, "err")
+}
+
+func TestGetDeletedUserPackageVersionsOrganization(t *testing.T) {
+	ClientRestExecutor = func(c *http.Client, req *http.Request) (*http.Response, error) {
+		checkGetRequest(req, "https://api.github.com/orgs/DummyOrg/packages/maven/DummyPackage/versions?state=deleted", t)
+		return createDefaultVersionsArrayResponse(), nil
+	}
+
+	versions, err := GetDeletedUserPackageVersions(context.Background(), restOrgConf.PackageName, &restOrgConf)
+
+	testutil.AssertNotNil(versions, t, "versions")
+	testutil.AssertEquals(1, len(*versions), t, "number of versions")
+	testutil.AssertNil(err, t, "err")
+}
+
+func TestRestoreUserPackageSuccessful(t *testing.T) {
+	ClientRestExecutor = func(c *http.Client, req *http.Request) (*http.Response, error) {
+		checkPostRequest(req, "https://api.github.com/users/DummyUser/packages/maven/DummyPackage/restore", t)
+		var body = ""
+		res := createResponse(&body, 204)
+		return res, nil
+	}
+
+	err := RestoreUserPackage(context.Background(), restConf.PackageName, &restConf)
+
+	testutil.AssertNil(err, t, "err")
+}
+
+func TestRestoreUserPackageWithErrorHttpStatus(t *testing.T) {
+	ClientRestExecutor = func(c *http.Client, req *http.Request) (*http.Response, error) {
+		var body = ""
+		res := createResponse(&body, 404)
+		return res, nil
+	}
+
+	err := RestoreUserPackage(context.Background(), restConf.PackageName, &restConf)
+
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertEquals("an error status code occured: 404 - Not Found", err.Error(), t, "error message")
+}
+
+func TestRestoreUserPackageVersionSuccessful(t *testing.T) {
+	ClientRestExecutor = func(c *http.Client, req *http.Request) (*http.Response, error) {
+		checkPostRequest(req, "https://api.github.com/orgs/DummyOrg/packages/maven/DummyPackage/versions/1/restore", t)
+		var body = ""
+		res := createResponse(&body, 204)
+		return res, nil
+	}
+
+	err := RestoreUserPackageVersion(context.Background(), restOrgConf.PackageName, 1, &restOrgConf)
+
+	testutil.AssertNil(err, t, "err")
+}
+
+func TestRestoreUserPackageVersionWithError(t *testing.T) {
+	ClientRestExecutor = func(c *http.Client, req *http.Request) (*http.Response, error) {
+		return nil, errors.New("SomeTestError")
+	}
+
+	err := RestoreUserPackageVersion(context.Background(), restConf.PackageName, 1, &restConf)
+
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertEquals("SomeTestError", err.Error(), t, "error message")
+}
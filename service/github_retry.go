@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ma-vin/packages-action/config"
+)
+
+const retry_after_header string = "Retry-After"
+const rate_limit_remaining_header string = "X-RateLimit-Remaining"
+const rate_limit_reset_header string = "X-RateLimit-Reset"
+
+// base duration of the exponential backoff between retries
+const retryBaseDelay time.Duration = time.Second
+
+// maximum exponent of the exponential backoff. Larger ones would overflow the duration
+const retryMaxBackoffExponent int = 30
+
+type Sleeper func(ctx context.Context, duration time.Duration) error
+
+var SleepExecutor Sleeper = initSleepExecutor()
+
+func initSleepExecutor() Sleeper {
+	return func(ctx context.Context, duration time.Duration) error {
+		return sleepContext(ctx, duration)
+	}
+}
+
+// Waits for the given duration, unless the context is done before. In this case the error of the context is returned
+func sleepContext(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Determines whether a request is to retry and how long to wait before. Responses of rate limits are retried for all methods, as long as the
+// requested waiting does not exceed the configured maximum. Server and network errors are only retried for requests without side effects.
+// The last result is a text of the reason to retry
+func (client GitHubRestClient) determineRetry(req *http.Request, response *http.Response, err error, attempt int, configuration *config.Config) (time.Duration, bool, string) {
+	if attempt >= configuration.MaxRetries {
+		return 0, false, ""
+	}
+	maxWait := time.Duration(configuration.RetryMaxWait) * time.Second
+
+	if err != nil {
+		return determineBackoff(attempt, maxWait), isRetryableMethod(req.Method), err.Error()
+	}
+
+	if isRateLimited(response) {
+		wait, requested := client.determineRateLimitWait(response)
+		if !requested {
+			wait = determineBackoff(attempt, maxWait)
+		}
+		return wait, wait <= maxWait, "rate limit " + strconv.Itoa(response.StatusCode)
+	}
+
+	if isRetryableStatus(response.StatusCode) {
+		return determineBackoff(attempt, maxWait), isRetryableMethod(req.Method), "server error " + strconv.Itoa(response.StatusCode)
+	}
+
+	return 0, false, ""
+}
+
+// Checks whether the response reports a primary or secondary rate limit
+func isRateLimited(response *http.Response) bool {
+	if response.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return response.StatusCode == http.StatusForbidden &&
+		(response.Header.Get(retry_after_header) != "" || response.Header.Get(rate_limit_remaining_header) == "0")
+}
+
+// Determines the waiting requested by GitHub either by the seconds of "Retry-After" or by the epoch seconds of "X-RateLimit-Reset" if there are no remaining requests.
+// The boolean result indicates whether any waiting was requested
+func (client GitHubRestClient) determineRateLimitWait(response *http.Response) (time.Duration, bool) {
+	if seconds, err := strconv.Atoi(response.Header.Get(retry_after_header)); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if response.Header.Get(rate_limit_remaining_header) != "0" {
+		return 0, false
+	}
+	if epochSeconds, err := strconv.ParseInt(response.Header.Get(rate_limit_reset_header), 10, 64); err == nil {
+		// one additional second, because the reset time has only a precision of seconds
+		return max(time.Unix(epochSeconds, 0).Sub(client.CurrentTime())+time.Second, 0), true
+	}
+	return 0, false
+}
+
+// Determines the exponential backoff of an attempt with jitter between the half and the full backoff, but not longer than the maximum
+func determineBackoff(attempt int, maxWait time.Duration) time.Duration {
+	backoff := retryBaseDelay << min(attempt, retryMaxBackoffExponent)
+	return min(backoff/2+rand.N(backoff/2+1), maxWait)
+}
+
+// Checks whether the status code indicates a temporary server error
+func isRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// Checks whether a method is without side effects, so a failed request can be repeated safely.
+// Deletions are not repeated, since their outcome is unknown if a server or network error occurs
+func isRetryableMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead
+}
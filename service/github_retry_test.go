@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ma-vin/packages-action/config"
+	"github.com/ma-vin/testutil-go"
+)
+
+var retryConf config.Config
+var sleepDurations []time.Duration
+var retryRequestCounter int
+
+func initRetryTest(responses ...func() (*http.Response, error)) {
+	retryConf = config.Config{GitHubRestUrl: "https://api.github.com", User: "DummyUser", PackageType: "maven", PackageName: "DummyPackage", MaxRetries: 3, RetryMaxWait: 60}
+	sleepDurations = []time.Duration{}
+	retryRequestCounter = 0
+
+	SleepExecutor = func(ctx context.Context, duration time.Duration) error {
+		sleepDurations = append(sleepDurations, duration)
+		return nil
+	}
+	CurrentTimeExecutor = func() time.Time {
+		return time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+	}
+	ClientRestExecutor = func(c *http.Client, req *http.Request) (*http.Response, error) {
+		response := responses[min(retryRequestCounter, len(responses)-1)]
+		retryRequestCounter++
+		return response()
+	}
+}
+
+func createStatusResponse(httpStatus int, header http.Header) func() (*http.Response, error) {
+	return func() (*http.Response, error) {
+		body := ""
+		res := createResponse(&body, httpStatus)
+		res.Header = http.Header{}
+		for name, values := range header {
+			res.Header.Set(name, values[0])
+		}
+		return res, nil
+	}
+}
+
+func TestGetUserPackageRetryServerError(t *testing.T) {
+	initRetryTest(createStatusResponse(502, nil), createStatusResponse(503, nil), func() (*http.Response, error) { return createDefaultPackageResponse(), nil })
+
+	userPackage, err := GetUserPackage(context.Background(), retryConf.PackageName, &retryConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(userPackage, t, "userPackage")
+	testutil.AssertEquals(3, retryRequestCounter, t, "number of requests")
+	testutil.AssertEquals(2, len(sleepDurations), t, "number of sleeps")
+	testutil.AssertTrue(sleepDurations[0] >= 500*time.Millisecond && sleepDurations[0] <= time.Second, t, "first backoff")
+	testutil.AssertTrue(sleepDurations[1] >= time.Second && sleepDurations[1] <= 2*time.Second, t, "second backoff")
+}
+
+func TestGetUserPackageRetryNetworkError(t *testing.T) {
+	initRetryTest(func() (*http.Response, error) { return nil, errors.New("SomeTestError") }, func() (*http.Response, error) { return createDefaultPackageResponse(), nil })
+
+	userPackage, err := GetUserPackage(context.Background(), retryConf.PackageName, &retryConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(userPackage, t, "userPackage")
+	testutil.AssertEquals(2, retryRequestCounter, t, "number of requests")
+	testutil.AssertEquals(1, len(sleepDurations), t, "number of sleeps")
+}
+
+func TestGetUserPackageRetryExhausted(t *testing.T) {
+	initRetryTest(createStatusResponse(500, nil))
+
+	userPackage, err := GetUserPackage(context.Background(), retryConf.PackageName, &retryConf)
+
+	testutil.AssertNil(userPackage, t, "userPackage")
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertEquals("an error status code occured: 500 - Internal Server Error", err.Error(), t, "error message")
+	testutil.AssertEquals(4, retryRequestCounter, t, "number of requests")
+	testutil.AssertEquals(3, len(sleepDurations), t, "number of sleeps")
+}
+
+func TestGetUserPackageNoRetryClientError(t *testing.T) {
+	initRetryTest(createStatusResponse(404, nil))
+
+	userPackage, err := GetUserPackage(context.Background(), retryConf.PackageName, &retryConf)
+
+	testutil.AssertNil(userPackage, t, "userPackage")
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertEquals(1, retryRequestCounter, t, "number of requests")
+	testutil.AssertEquals(0, len(sleepDurations), t, "number of sleeps")
+}
+
+func TestGetUserPackageRetryAfter(t *testing.T) {
+	initRetryTest(createStatusResponse(403, http.Header{retry_after_header: []string{"30"}}), func() (*http.Response, error) { return createDefaultPackageResponse(), nil })
+
+	userPackage, err := GetUserPackage(context.Background(), retryConf.PackageName, &retryConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(userPackage, t, "userPackage")
+	testutil.AssertEquals(2, retryRequestCounter, t, "number of requests")
+	testutil.AssertEquals(1, len(sleepDurations), t, "number of sleeps")
+	testutil.AssertEquals(30*time.Second, sleepDurations[0], t, "waiting of retry after")
+}
+
+func TestGetUserPackageRateLimitReset(t *testing.T) {
+	reset := strconv.FormatInt(time.Date(2024, 4, 1, 12, 0, 20, 0, time.UTC).Unix(), 10)
+	initRetryTest(createStatusResponse(403, http.Header{rate_limit_remaining_header: []string{"0"}, rate_limit_reset_header: []string{reset}}),
+		func() (*http.Response, error) { return createDefaultPackageResponse(), nil })
+
+	userPackage, err := GetUserPackage(context.Background(), retryConf.PackageName, &retryConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(userPackage, t, "userPackage")
+	testutil.AssertEquals(2, retryRequestCounter, t, "number of requests")
+	testutil.AssertEquals(1, len(sleepDurations), t, "number of sleeps")
+	testutil.AssertEquals(21*time.Second, sleepDurations[0], t, "waiting until reset")
+}
+
+func TestGetUserPackageRateLimitResetTooLate(t *testing.T) {
+	reset := strconv.FormatInt(time.Date(2024, 4, 1, 13, 0, 0, 0, time.UTC).Unix(), 10)
+	initRetryTest(createStatusResponse(403, http.Header{rate_limit_remaining_header: []string{"0"}, rate_limit_reset_header: []string{reset}}))
+
+	userPackage, err := GetUserPackage(context.Background(), retryConf.PackageName, &retryConf)
+
+	testutil.AssertNil(userPackage, t, "userPackage")
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertEquals("an error status code occured: 403 - Forbidden", err.Error(), t, "error message")
+	testutil.AssertEquals(1, retryRequestCounter, t, "number of requests")
+	testutil.AssertEquals(0, len(sleepDurations), t, "number of sleeps")
+}
+
+func TestGetUserPackageNoRetryForbidden(t *testing.T) {
+	initRetryTest(createStatusResponse(403, http.Header{rate_limit_remaining_header: []string{"4999"}}))
+
+	userPackage, err := GetUserPackage(context.Background(), retryConf.PackageName, &retryConf)
+
+	testutil.AssertNil(userPackage, t, "userPackage")
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertEquals(1, retryRequestCounter, t, "number of requests")
+}
+
+func TestDeleteUserPackageVersionRetryTooManyRequests(t *testing.T) {
+	initRetryTest(createStatusResponse(429, nil), createStatusResponse(204, nil))
+
+	err := DeleteUserPackageVersion(context.Background(), retryConf.PackageName, 123, &retryConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertEquals(2, retryRequestCounter, t, "number of requests")
+	testutil.AssertEquals(1, len(sleepDurations), t, "number of sleeps")
+}
+
+func TestDeleteUserPackageVersionNoRetryServerError(t *testing.T) {
+	initRetryTest(createStatusResponse(502, nil), createStatusResponse(204, nil))
+
+	err := DeleteUserPackageVersion(context.Background(), retryConf.PackageName, 123, &retryConf)
+
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertEquals(1, retryRequestCounter, t, "number of requests")
+	testutil.AssertEquals(0, len(sleepDurations), t, "number of sleeps")
+}
+
+func TestDetermineBackoffMaximum(t *testing.T) {
+	testutil.AssertEquals(5*time.Second, determineBackoff(10, 5*time.Second), t, "backoff limited by maximum")
+}
+
+func TestDetermineBackoffLargeAttempt(t *testing.T) {
+	for _, attempt := range []int{33, 34, 40, 100} {
+		testutil.AssertEquals(5*time.Second, determineBackoff(attempt, 5*time.Second), t, "backoff of large attempt")
+	}
+}
+
+func TestGetUserPackageRetryCancelled(t *testing.T) {
+	initRetryTest(createStatusResponse(502, nil), func() (*http.Response, error) { return createDefaultPackageResponse(), nil })
+	ctx, cancel := context.WithCancel(context.Background())
+	SleepExecutor = func(ctx context.Context, duration time.Duration) error {
+		cancel()
+		return sleepContext(ctx, duration)
+	}
+
+	userPackage, err := GetUserPackage(ctx, retryConf.PackageName, &retryConf)
+
+	testutil.AssertNil(userPackage, t, "userPackage")
+	testutil.AssertTrue(errors.Is(err, context.Canceled), t, "cancelled error")
+	testutil.AssertEquals(1, retryRequestCounter, t, "number of requests")
+}
+
+func TestSleepContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	testutil.AssertNil(sleepContext(ctx, time.Millisecond), t, "sleep without cancellation")
+	cancel()
+	testutil.AssertTrue(errors.Is(sleepContext(ctx, time.Hour), context.Canceled), t, "sleep after cancellation")
+}
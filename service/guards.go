@@ -0,0 +1,88 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/ma-vin/packages-action/config"
+	"github.com/ma-vin/packages-action/service/github_model"
+	"github.com/ma-vin/typewriter/logger"
+)
+
+// Keeps the candidates which are one of the configured minimum number of newest versions of the package, whichever rule matched them.
+// Returns the remaining candidates and the kept versions extended by the ones which are kept additionally
+func applyMinVersionsToKeep(versions *[]github_model.Version, candidates *[]Candidate, keptVersions []string, config *config.Config) (*[]Candidate, []string) {
+	if config.MinVersionsToKeep <= 0 || len(*candidates) == 0 {
+		return candidates, keptVersions
+	}
+	newestIds := determineNewestVersionIds(versions, config.MinVersionsToKeep)
+
+	res := []Candidate{}
+	for _, c := range *candidates {
+		if newestIds[c.Id] {
+			logger.Informationf("Keep version '%s' of package %s as one of the %d newest versions", c.Name, config.PackageName, config.MinVersionsToKeep)
+			keptVersions = append(keptVersions, c.Name)
+			continue
+		}
+		res = append(res, c)
+	}
+	return &res, keptVersions
+}
+
+// Determines the ids of the given number of newest versions by their last change. Versions without parseable point in time
+// are considered older than the others and keep their order of the listing
+func determineNewestVersionIds(versions *[]github_model.Version, number int) map[int]bool {
+	sorted := slices.Clone(*versions)
+	slices.SortStableFunc(sorted, func(a github_model.Version, b github_model.Version) int {
+		aLastChange, aOk := determineLastChange(&a)
+		bLastChange, bOk := determineLastChange(&b)
+		switch {
+		case aOk && bOk:
+			return bLastChange.Compare(aLastChange)
+		case aOk:
+			return -1
+		case bOk:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	res := make(map[int]bool)
+	for _, v := range sorted[:min(number, len(sorted))] {
+		res[v.Id] = true
+	}
+	return res
+}
+
+// Counts the versions which are deleted by the candidates of all packages. A package candidate counts with all of its versions
+func countVersionsToDelete(packageCandidates *[]PackageCandidates) int {
+	count := 0
+	for _, pc := range *packageCandidates {
+		for _, c := range *pc.Candidates {
+			if c.Type == PACKAGE_CANDIDATE {
+				count += max(c.NumberOfVersions, 1)
+			} else {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// Checks whether there are not more versions to delete than the configured maximum per run. If there are more, an error is returned
+// unless the deletion is forced
+func checkMaxVersionsToDelete(packageCandidates *[]PackageCandidates, config *config.Config) error {
+	if config.MaxVersionsToDelete <= 0 {
+		return nil
+	}
+	count := countVersionsToDelete(packageCandidates)
+	if count <= config.MaxVersionsToDelete {
+		return nil
+	}
+	if config.Force {
+		logger.Warningf("%d versions are to delete, which exceeds the maximum of %d versions per run: delete anyway because it is forced", count, config.MaxVersionsToDelete)
+		return nil
+	}
+	return fmt.Errorf("deletion aborted: %d versions are to delete, which exceeds the maximum of %d versions per run", count, config.MaxVersionsToDelete)
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ma-vin/packages-action/config"
+	"github.com/ma-vin/packages-action/service/github_model"
+	"github.com/ma-vin/testutil-go"
+)
+
+func TestDetermineCandidatesMinVersionsToKeep(t *testing.T) {
+	initCandidateTest()
+
+	candidatesConf.DeleteSnapshots = true
+	candidatesConf.MinVersionsToKeep = 2
+	candidateVersionOne.Name = "1.0.0-SNAPSHOT"
+	candidateVersionTwo.Name = "2.0.0-SNAPSHOT"
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(candidates, t, "candidates")
+	testutil.AssertEquals(1, len(*candidates), t, "len candidates")
+	testutil.AssertEquals(2, (*candidates)[0].Id, t, "id")
+}
+
+func TestDetermineCandidatesMinVersionsToKeepPreventsEmptyPackage(t *testing.T) {
+	initCandidateTest()
+
+	candidatesConf.EmptyPackagePolicy = config.EMPTY_PACKAGE_DELETE_PACKAGE
+	candidatesConf.VersionPatternsToDelete = []string{"*"}
+	candidatesConf.MinVersionsToKeep = 1
+	candidatesConf.PackageNames = []string{"DummyPackage"}
+
+	packageCandidates, err := DetermineAllCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(packageCandidates, t, "package candidates")
+	testutil.AssertEquals(2, len(*(*packageCandidates)[0].Candidates), t, "len candidates")
+	testutil.AssertEquals(VERSION_CANDIDATE, (*(*packageCandidates)[0].Candidates)[0].Type, t, "type")
+	testutil.AssertEquals("1.1.1", (*packageCandidates)[0].KeptVersions[0], t, "kept version")
+	testutil.AssertEquals("", (*packageCandidates)[0].EmptyPackagePolicy, t, "empty package policy")
+}
+
+func TestDetermineNewestVersionIdsWithoutParseableTime(t *testing.T) {
+	initCandidateTest()
+
+	candidateVersionOne.UpdatedAt = "2024-03-25T16:00:00Z"
+	candidateVersionTwo.CreatedAt = ""
+	candidateVersionTwo.UpdatedAt = ""
+
+	newestIds := determineNewestVersionIds(&[]github_model.Version{candidateVersionTwo, candidateVersionOne, candidateVersionThreee}, 2)
+
+	testutil.AssertEquals(2, len(newestIds), t, "len newest ids")
+	testutil.AssertTrue(newestIds[candidateVersionOne.Id], t, "first version newest")
+	testutil.AssertTrue(newestIds[candidateVersionThreee.Id], t, "third version newest")
+	testutil.AssertFalse(newestIds[candidateVersionTwo.Id], t, "second version newest")
+}
+
+func TestCountVersionsToDelete(t *testing.T) {
+	packageCandidates := []PackageCandidates{
+		{"DummyPackage", &[]Candidate{{Id: 2, Type: VERSION_CANDIDATE}, {Id: 3, Type: VERSION_CANDIDATE}}, []string{}, "", ""},
+		{"OtherPackage", &[]Candidate{{Id: 5, Type: PACKAGE_CANDIDATE, NumberOfVersions: 4}}, []string{}, config.EMPTY_PACKAGE_DELETE_PACKAGE, ""},
+	}
+
+	testutil.AssertEquals(6, countVersionsToDelete(&packageCandidates), t, "count")
+}
+
+func TestCheckMaxVersionsToDelete(t *testing.T) {
+	packageCandidates := []PackageCandidates{
+		{"DummyPackage", &[]Candidate{{Id: 2, Type: VERSION_CANDIDATE}, {Id: 3, Type: VERSION_CANDIDATE}}, []string{}, "", ""},
+	}
+
+	testutil.AssertNil(checkMaxVersionsToDelete(&packageCandidates, &config.Config{MaxVersionsToDelete: -1}), t, "err without maximum")
+	testutil.AssertNil(checkMaxVersionsToDelete(&packageCandidates, &config.Config{MaxVersionsToDelete: 2}), t, "err at maximum")
+	testutil.AssertNotNil(checkMaxVersionsToDelete(&packageCandidates, &config.Config{MaxVersionsToDelete: 1}), t, "err above maximum")
+	testutil.AssertNil(checkMaxVersionsToDelete(&packageCandidates, &config.Config{MaxVersionsToDelete: 1, Force: true}), t, "err above maximum forced")
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ma-vin/packages-action/config"
+	"github.com/ma-vin/packages-action/service/github_model"
+	"github.com/ma-vin/typewriter/logger"
+)
+
+// Lists the packages of the configured names or pattern with their versions. If neither names nor pattern are configured,
+// all packages of the package type are listed. Nothing is deleted
+func ListPackages(ctx context.Context, configuration *config.Config) error {
+	return newExecutorCleaner(configuration).ListPackages(ctx)
+}
+
+// Lists the packages of the configuration of the cleaner with their versions. See ListPackages
+func (c *Cleaner) ListPackages(ctx context.Context) error {
+	configuration := c.configuration
+	packages, err := c.Repository.GetPackages(ctx, configuration)
+	if err != nil {
+		return err
+	}
+	packageNames, err := determinePackageNamesToList(packages, configuration)
+	if err != nil {
+		return err
+	}
+	if len(packageNames) == 0 {
+		logger.Informationf("There does not exists any package of type %s to list", configuration.PackageType)
+		return nil
+	}
+
+	for _, packageName := range packageNames {
+		packageConfig := *configuration
+		packageConfig.PackageName = packageName
+		versions, err := c.Repository.GetVersions(ctx, &packageConfig)
+		if err != nil {
+			return err
+		}
+		logPackageVersions(packageName, versions)
+	}
+	return nil
+}
+
+// Determines the names of the packages to list: those of the configuration, of the package configurations of a configuration file
+// or, if there is neither a name, a pattern nor a repository, all existing ones
+func determinePackageNamesToList(packages *[]github_model.UserPackage, configuration *config.Config) ([]string, error) {
+	packageConfigs := configuration.Packages
+	if len(packageConfigs) == 0 {
+		packageConfigs = []config.Config{*configuration}
+	}
+
+	res := []string{}
+	for i := range packageConfigs {
+		if len(packageConfigs[i].PackageNames) == 0 && packageConfigs[i].PackageNamePattern == "" && packageConfigs[i].Repository == "" {
+			continue
+		}
+		packageNames, err := determinePackageNames(packages, &packageConfigs[i])
+		if err != nil {
+			return nil, err
+		}
+		for _, packageName := range packageNames {
+			if !slices.Contains(res, packageName) {
+				res = append(res, packageName)
+			}
+		}
+	}
+	if len(res) > 0 || len(configuration.PackageNames) > 0 || configuration.PackageNamePattern != "" || configuration.Repository != "" || len(configuration.Packages) > 0 {
+		return res, nil
+	}
+
+	for _, p := range *packages {
+		res = append(res, p.Name)
+	}
+	return res, nil
+}
+
+// logs a package with its versions, their ids, last changes and, if there are any, tags
+func logPackageVersions(packageName string, versions *[]github_model.Version) {
+	logger.Informationf("Package %s with %d versions", packageName, len(*versions))
+	for _, v := range *versions {
+		logger.Information("  ", formatListedVersion(&v))
+	}
+}
+
+// formats a listed version with its id, last change and, if there are any, tags
+func formatListedVersion(version *github_model.Version) string {
+	lastChange := "unknown"
+	if changedAt, ok := determineLastChange(version); ok {
+		lastChange = changedAt.Format(time.RFC3339)
+	}
+	res := fmt.Sprintf("%s (id %d, last change %s)", version.Name, version.Id, lastChange)
+	if tags := getTags(version); len(tags) > 0 {
+		res += " tags: " + strings.Join(tags, ", ")
+	}
+	return res
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ma-vin/packages-action/config"
+	"github.com/ma-vin/packages-action/service/github_model"
+	"github.com/ma-vin/testutil-go"
+)
+
+var listedPackageNames []string
+
+func initListTest() {
+	initMultiplePackagesCandidateTest()
+	listedPackageNames = []string{}
+
+	VersionsGetExecutor = func(ctx context.Context, config *config.Config) (*[]github_model.Version, error) {
+		listedPackageNames = append(listedPackageNames, config.PackageName)
+		return &[]github_model.Version{candidateVersionOne, candidateVersionTwo}, nil
+	}
+	candidatesConf.Mode = config.MODE_LIST
+	candidatesConf.PackageName = ""
+}
+
+func TestListPackagesAll(t *testing.T) {
+	initListTest()
+
+	err := ListPackages(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertEquals(3, len(listedPackageNames), t, "len listed packages")
+	testutil.AssertEquals("DummyPackage", listedPackageNames[0], t, "first listed package")
+	testutil.AssertEquals("OtherPackage", listedPackageNames[1], t, "second listed package")
+	testutil.AssertEquals("AnotherPackage", listedPackageNames[2], t, "third listed package")
+}
+
+func TestListPackagesNamesAndPattern(t *testing.T) {
+	initListTest()
+
+	candidatesConf.PackageNames = []string{"AnotherPackage", "MissingPackage"}
+	candidatesConf.PackageNamePattern = "Other*"
+
+	err := ListPackages(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertEquals(2, len(listedPackageNames), t, "len listed packages")
+	testutil.AssertEquals("AnotherPackage", listedPackageNames[0], t, "first listed package")
+	testutil.AssertEquals("OtherPackage", listedPackageNames[1], t, "second listed package")
+}
+
+func TestListPackagesRepository(t *testing.T) {
+	initListTest()
+	initRepositoryPackages()
+
+	candidatesConf.Repository = "Ma-Vin/other-repo"
+
+	err := ListPackages(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertEquals(1, len(listedPackageNames), t, "len listed packages")
+	testutil.AssertEquals("OtherPackage", listedPackageNames[0], t, "first listed package")
+}
+
+func TestListPackagesNoMatch(t *testing.T) {
+	initListTest()
+
+	candidatesConf.PackageNames = []string{"MissingPackage"}
+
+	err := ListPackages(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertEquals(0, len(listedPackageNames), t, "len listed packages")
+}
+
+func TestListPackagesGetVersionsError(t *testing.T) {
+	initListTest()
+
+	VersionsGetExecutor = func(ctx context.Context, config *config.Config) (*[]github_model.Version, error) {
+		return nil, errors.New("some error")
+	}
+
+	err := ListPackages(context.Background(), &candidatesConf)
+
+	testutil.AssertNotNil(err, t, "err")
+}
+
+func TestFormatListedVersion(t *testing.T) {
+	version := github_model.Version{Id: 3, Name: "sha256:2222", CreatedAt: "2024-03-13T20:00:00Z", UpdatedAt: "2024-03-14T16:00:00Z",
+		Metadata: github_model.Metadata{PackageType: github_model.CONTAINER, Container: github_model.Container{Tags: []string{"1.0.0", "latest"}}}}
+
+	testutil.AssertEquals("sha256:2222 (id 3, last change 2024-03-14T16:00:00Z) tags: 1.0.0, latest", formatListedVersion(&version), t, "formatted version")
+}
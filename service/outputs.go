@@ -0,0 +1,113 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ma-vin/packages-action/config"
+)
+
+const (
+	OUTPUT_DELETED_COUNT    string = "deleted-count"
+	OUTPUT_DELETED_VERSIONS string = "deleted-versions"
+	OUTPUT_KEPT_VERSIONS    string = "kept-versions"
+	OUTPUT_PACKAGE_DELETED  string = "package-deleted"
+	OUTPUT_DRY_RUN          string = "dry-run"
+)
+
+// prefix of the delimiter of multiline output values
+const outputDelimiterPrefix string = "ghadelimiter_"
+
+type OutputWriter func(report *Report, packageCandidates *[]PackageCandidates, config *config.Config) error
+
+var OutputExecutor OutputWriter = initOutputExecutor()
+
+func initOutputExecutor() OutputWriter {
+	return func(report *Report, packageCandidates *[]PackageCandidates, config *config.Config) error {
+		return WriteOutputs(report, packageCandidates, config)
+	}
+}
+
+// Appends the action outputs to the configured GitHub output file. If there is none, nothing is written.
+// The deleted elements are those of the report which are deleted or would be deleted at dry run: "<package>@<version>" for versions and "<package>" for whole packages.
+// The kept versions are those which are not candidates or whose deletion failed, was aborted or cancelled. Versions whose deletion was
+// interrupted are in neither list, since it is unknown whether they still exist
+func WriteOutputs(report *Report, packageCandidates *[]PackageCandidates, config *config.Config) error {
+	if config.OutputFile == "" {
+		return nil
+	}
+
+	var deleted, kept []string
+	packageDeleted := false
+	packageType := PACKAGE_CANDIDATE
+	for _, e := range report.Entries {
+		isPackage := e.Type == getCandidateTypeText(&packageType)
+		switch {
+		case e.Result == RESULT_UNKNOWN:
+			continue
+		case isPackage && !isKeptResult(e.Result):
+			packageDeleted = true
+			deleted = append(deleted, e.Package)
+		case !isPackage && isKeptResult(e.Result):
+			kept = append(kept, formatPackageVersion(e.Package, e.Name))
+		case !isPackage:
+			deleted = append(deleted, formatPackageVersion(e.Package, e.Name))
+		}
+	}
+	for _, pc := range *packageCandidates {
+		for _, versionName := range pc.KeptVersions {
+			kept = append(kept, formatPackageVersion(pc.PackageName, versionName))
+		}
+	}
+
+	var sb strings.Builder
+	writeOutput(&sb, OUTPUT_DELETED_COUNT, strconv.Itoa(len(deleted)))
+	writeOutput(&sb, OUTPUT_DELETED_VERSIONS, strings.Join(deleted, "\n"))
+	writeOutput(&sb, OUTPUT_KEPT_VERSIONS, strings.Join(kept, "\n"))
+	writeOutput(&sb, OUTPUT_PACKAGE_DELETED, strconv.FormatBool(packageDeleted))
+	writeOutput(&sb, OUTPUT_DRY_RUN, strconv.FormatBool(config.DryRun))
+
+	file, err := os.OpenFile(config.OutputFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = file.WriteString(sb.String())
+	return err
+}
+
+// formats a version of a package as "<package>@<version>"
+func formatPackageVersion(packageName string, versionName string) string {
+	return packageName + "@" + versionName
+}
+
+// writes an output as "<name>=<value>" or, if the value contains line breaks, with a delimiter which is not part of the value
+func writeOutput(sb *strings.Builder, name string, value string) {
+	if !strings.ContainsAny(value, "\r\n") {
+		fmt.Fprintf(sb, "%s=%s\n", name, value)
+		return
+	}
+	delimiter := createOutputDelimiter(value)
+	fmt.Fprintf(sb, "%s<<%s\n%s\n%s\n", name, delimiter, value, delimiter)
+}
+
+// creates a random delimiter which is not contained by the value
+func createOutputDelimiter(value string) string {
+	for {
+		randomBytes := make([]byte, 16)
+		rand.Read(randomBytes)
+		delimiter := outputDelimiterPrefix + hex.EncodeToString(randomBytes)
+		if !strings.Contains(value, delimiter) {
+			return delimiter
+		}
+	}
+}
+
+// Checks whether the result of a report entry means that its version or package still exists
+func isKeptResult(result string) bool {
+	return result == RESULT_FAILED || result == RESULT_ABORTED || result == RESULT_CANCELLED
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/ma-vin/packages-action/config"
+	"github.com/ma-vin/testutil-go"
+)
+
+// parses the content of a GitHub output file into a map of names and values
+func parseOutputs(content string) map[string]string {
+	result := map[string]string{}
+	lines := strings.Split(content, "\n")
+	for i := 0; i < len(lines); i++ {
+		if name, delimiter, found := strings.Cut(lines[i], "<<"); found {
+			var values []string
+			for i++; lines[i] != delimiter; i++ {
+				values = append(values, lines[i])
+			}
+			result[name] = strings.Join(values, "\n")
+			continue
+		}
+		if name, value, found := strings.Cut(lines[i], "="); found {
+			result[name] = value
+		}
+	}
+	return result
+}
+
+func TestWriteOutputs(t *testing.T) {
+	outputConf := config.Config{OutputFile: filepath.Join(t.TempDir(), "output")}
+	os.WriteFile(outputConf.OutputFile, []byte("existing=value\n"), 0644)
+
+	tasks := []deletionTask{
+		{0, "DummyPackage", Candidate{Id: 2, Name: "1.0.0", Type: VERSION_CANDIDATE}},
+		{1, "DummyPackage", Candidate{Id: 3, Name: "1.1.0", Type: VERSION_CANDIDATE}},
+		{2, "OtherPackage", Candidate{Id: 5, Name: "OtherPackage", Type: PACKAGE_CANDIDATE}},
+		{3, "DummyPackage", Candidate{Id: 4, Name: "1.2.0", Type: VERSION_CANDIDATE}},
+		{4, "ThirdPackage", Candidate{Id: 6, Name: "ThirdPackage", Type: PACKAGE_CANDIDATE}},
+	}
+	results := []deletionResult{{tasks[0], nil, false, false}, {tasks[1], errors.New("testError"), false, false}, {tasks[2], nil, false, false},
+		{tasks[3], context.Canceled, false, true}, {tasks[4], context.Canceled, false, true}}
+	packageCandidates := []PackageCandidates{{"DummyPackage", &[]Candidate{tasks[0].candidate, tasks[1].candidate, tasks[3].candidate}, []string{"1.1.1"}, "", ""},
+		{"OtherPackage", &[]Candidate{tasks[2].candidate}, []string{}, config.EMPTY_PACKAGE_DELETE_PACKAGE, ""},
+		{"ThirdPackage", &[]Candidate{tasks[4].candidate}, []string{}, config.EMPTY_PACKAGE_DELETE_PACKAGE, ""}}
+
+	err := WriteOutputs(createReport(tasks, results, &outputConf), &packageCandidates, &outputConf)
+	testutil.AssertNil(err, t, "err")
+
+	content, err := os.ReadFile(outputConf.OutputFile)
+	testutil.AssertNil(err, t, "err read output")
+	testutil.AssertTrue(strings.HasPrefix(string(content), "existing=value\n"), t, "appended outputs")
+	outputs := parseOutputs(string(content))
+	testutil.AssertEquals("2", outputs[OUTPUT_DELETED_COUNT], t, "deleted count")
+	testutil.AssertEquals("DummyPackage@1.0.0\nOtherPackage", outputs[OUTPUT_DELETED_VERSIONS], t, "deleted versions")
+	testutil.AssertEquals("DummyPackage@1.1.0\nDummyPackage@1.1.1", outputs[OUTPUT_KEPT_VERSIONS], t, "kept versions")
+	testutil.AssertEquals("true", outputs[OUTPUT_PACKAGE_DELETED], t, "package deleted")
+	testutil.AssertEquals("false", outputs[OUTPUT_DRY_RUN], t, "dry run")
+	testutil.AssertTrue(regexp.MustCompile(`(?m)^deleted-versions<<ghadelimiter_[0-9a-f]{32}$`).Match(content), t, "delimiter of deleted versions")
+}
+
+func TestWriteOutputsDryRunSingleLine(t *testing.T) {
+	outputConf := config.Config{DryRun: true, OutputFile: filepath.Join(t.TempDir(), "output")}
+
+	tasks := []deletionTask{{0, "DummyPackage", Candidate{Id: 2, Name: "1.0.0", Type: VERSION_CANDIDATE}}}
+	packageCandidates := []PackageCandidates{{"DummyPackage", &[]Candidate{tasks[0].candidate}, []string{}, "", ""}}
+
+	err := WriteOutputs(createReport(tasks, nil, &outputConf), &packageCandidates, &outputConf)
+	testutil.AssertNil(err, t, "err")
+
+	content, err := os.ReadFile(outputConf.OutputFile)
+	testutil.AssertNil(err, t, "err read output")
+	testutil.AssertEquals("deleted-count=1\ndeleted-versions=DummyPackage@1.0.0\nkept-versions=\npackage-deleted=false\ndry-run=true\n", string(content), t, "outputs")
+}
+
+func TestWriteOutputsInterruptedPackage(t *testing.T) {
+	outputConf := config.Config{OutputFile: filepath.Join(t.TempDir(), "output")}
+
+	tasks := []deletionTask{{0, "DummyPackage", Candidate{Id: 5, Name: "DummyPackage", Type: PACKAGE_CANDIDATE}}}
+	results := []deletionResult{{tasks[0], context.Canceled, false, true}}
+	packageCandidates := []PackageCandidates{{"DummyPackage", &[]Candidate{tasks[0].candidate}, []string{}, config.EMPTY_PACKAGE_DELETE_PACKAGE, ""}}
+
+	err := WriteOutputs(createReport(tasks, results, &outputConf), &packageCandidates, &outputConf)
+	testutil.AssertNil(err, t, "err")
+
+	content, err := os.ReadFile(outputConf.OutputFile)
+	testutil.AssertNil(err, t, "err read output")
+	testutil.AssertEquals("deleted-count=0\ndeleted-versions=\nkept-versions=\npackage-deleted=false\ndry-run=false\n", string(content), t, "outputs")
+}
+
+func TestWriteOutputsWithoutFile(t *testing.T) {
+	err := WriteOutputs(&Report{}, &[]PackageCandidates{}, &config.Config{})
+	testutil.AssertNil(err, t, "err")
+}
+
+func TestWriteOutputsNotWritable(t *testing.T) {
+	outputConf := config.Config{OutputFile: filepath.Join(t.TempDir(), "missing", "output")}
+
+	err := WriteOutputs(&Report{}, &[]PackageCandidates{}, &outputConf)
+	testutil.AssertNotNil(err, t, "err")
+}
+
+func TestCreateOutputDelimiter(t *testing.T) {
+	delimiter := createOutputDelimiter("a\nb")
+	testutil.AssertTrue(strings.HasPrefix(delimiter, outputDelimiterPrefix), t, "delimiter prefix")
+	testutil.AssertFalse(strings.Contains("a\nb", delimiter), t, "delimiter not in value")
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ma-vin/packages-action/config"
+	"github.com/ma-vin/packages-action/service/github_model"
+	"github.com/ma-vin/typewriter/logger"
+)
+
+// Plan of the candidates to delete, which is applied later on without determining the candidates again
+type Plan struct {
+	CreatedAt   string        `json:"created_at"`
+	Owner       string        `json:"owner"`
+	PackageType string        `json:"package_type"`
+	Packages    []PlanPackage `json:"packages"`
+}
+
+// The candidates of a package at a plan and the hash of the versions they were determined from
+type PlanPackage struct {
+	Package            string          `json:"package"`
+	VersionsHash       string          `json:"versions_hash"`
+	Candidates         []PlanCandidate `json:"candidates"`
+	KeptVersions       []string        `json:"kept_versions"`
+	EmptyPackagePolicy string          `json:"empty_package_policy,omitempty"`
+}
+
+// A candidate of a plan
+type PlanCandidate struct {
+	Id               int           `json:"id"`
+	Name             string        `json:"name"`
+	Type             string        `json:"type"`
+	MatchedRules     []MatchedRule `json:"matched_rules,omitempty"`
+	NumberOfVersions int           `json:"number_of_versions,omitempty"`
+}
+
+type PlanWriter func(packageCandidates *[]PackageCandidates, config *config.Config) error
+
+var PlanExecutor PlanWriter = initPlanExecutor()
+
+func initPlanExecutor() PlanWriter {
+	return func(packageCandidates *[]PackageCandidates, config *config.Config) error {
+		return WritePlan(packageCandidates, config)
+	}
+}
+
+// Writes the candidates as json plan to the configured plan file. Without a plan file nothing is written
+func WritePlan(packageCandidates *[]PackageCandidates, config *config.Config) error {
+	if config.PlanFile == "" {
+		return nil
+	}
+	content, err := json.MarshalIndent(createPlan(packageCandidates, config), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(config.PlanFile, content, 0644); err != nil {
+		return fmt.Errorf("failed to write plan %s: %w", config.PlanFile, err)
+	}
+	logger.Informationf("Plan written to %s", config.PlanFile)
+	return nil
+}
+
+// Creates the plan of the candidates of the packages
+func createPlan(packageCandidates *[]PackageCandidates, config *config.Config) *Plan {
+	_, owner := getOwnerUrlParts(config)
+	plan := Plan{CreatedAt: CurrentTimeExecutor().Format(time.RFC3339), Owner: owner, PackageType: config.PackageType, Packages: []PlanPackage{}}
+	for _, pc := range *packageCandidates {
+		planPackage := PlanPackage{Package: pc.PackageName, VersionsHash: pc.VersionsHash, Candidates: []PlanCandidate{}, KeptVersions: pc.KeptVersions,
+			EmptyPackagePolicy: pc.EmptyPackagePolicy}
+		for _, c := range *pc.Candidates {
+			planPackage.Candidates = append(planPackage.Candidates, PlanCandidate{Id: c.Id, Name: c.Name, Type: getCandidateTypeText(&c.Type),
+				MatchedRules: c.MatchedRules, NumberOfVersions: c.NumberOfVersions})
+		}
+		plan.Packages = append(plan.Packages, planPackage)
+	}
+	return &plan
+}
+
+// Reads a json plan which was written by a previous run
+func ReadPlan(planFile string) (*Plan, error) {
+	content, err := os.ReadFile(planFile)
+	if err != nil {
+		return nil, err
+	}
+	var plan Plan
+	if err = json.Unmarshal(content, &plan); err != nil {
+		return nil, fmt.Errorf("the plan %s is not valid: %w", planFile, err)
+	}
+	return &plan, nil
+}
+
+// Deletes exactly the candidates of the configured plan file. The deletion is refused if the plan belongs to another owner or
+// package type or if the versions of any package changed since the plan was created
+func ApplyPlan(ctx context.Context, config *config.Config) error {
+	return newExecutorCleaner(config).ApplyPlan(ctx)
+}
+
+// Deletes exactly the candidates of the plan file of the configuration of the cleaner. See ApplyPlan
+func (c *Cleaner) ApplyPlan(ctx context.Context) error {
+	plan, err := ReadPlan(c.configuration.PlanFile)
+	if err != nil {
+		return err
+	}
+	packageCandidates, err := c.determinePlannedCandidates(ctx, plan)
+	if err != nil {
+		return err
+	}
+	return c.deletePackageCandidates(ctx, packageCandidates)
+}
+
+// Determines the candidates of a plan after checking that the plan matches the configuration and the current versions of its packages
+func (c *Cleaner) determinePlannedCandidates(ctx context.Context, plan *Plan) (*[]PackageCandidates, error) {
+	configuration := c.configuration
+	if _, owner := getOwnerUrlParts(configuration); plan.Owner != owner || plan.PackageType != configuration.PackageType {
+		return nil, fmt.Errorf("the plan %s is for %s packages of %s, but %s packages of %s are configured", configuration.PlanFile,
+			plan.PackageType, plan.Owner, configuration.PackageType, owner)
+	}
+
+	res := []PackageCandidates{}
+	var driftedPackages []string
+	for _, planPackage := range plan.Packages {
+		packageConfig := *configuration
+		packageConfig.PackageName = planPackage.Package
+		versions, err := c.Repository.GetVersions(ctx, &packageConfig)
+		if err != nil {
+			return nil, err
+		}
+		if determineVersionsHash(versions) != planPackage.VersionsHash {
+			driftedPackages = append(driftedPackages, planPackage.Package)
+			continue
+		}
+		packageCandidates, err := createPlannedPackageCandidates(&planPackage, configuration.PlanFile)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *packageCandidates)
+	}
+	if len(driftedPackages) > 0 {
+		return nil, fmt.Errorf("the plan %s is outdated: the versions of packages %s changed since it was created", configuration.PlanFile, strings.Join(driftedPackages, ", "))
+	}
+	return &res, nil
+}
+
+// Creates the candidates of a package of a plan. A candidate whose type is neither a version nor a package is an error
+func createPlannedPackageCandidates(planPackage *PlanPackage, planFile string) (*PackageCandidates, error) {
+	versionType, packageType := VERSION_CANDIDATE, PACKAGE_CANDIDATE
+	candidates := []Candidate{}
+	for _, c := range planPackage.Candidates {
+		var candidateType int
+		switch c.Type {
+		case getCandidateTypeText(&versionType):
+			candidateType = VERSION_CANDIDATE
+		case getCandidateTypeText(&packageType):
+			candidateType = PACKAGE_CANDIDATE
+		default:
+			return nil, fmt.Errorf("the plan %s is not valid: the candidate %s with id %d of package %s has the unknown type '%s'", planFile,
+				c.Name, c.Id, planPackage.Package, c.Type)
+		}
+		candidates = append(candidates, Candidate{Id: c.Id, Name: c.Name, Type: candidateType, MatchedRules: c.MatchedRules, NumberOfVersions: c.NumberOfVersions})
+	}
+	return &PackageCandidates{PackageName: planPackage.Package, Candidates: &candidates, KeptVersions: planPackage.KeptVersions,
+		EmptyPackagePolicy: planPackage.EmptyPackagePolicy, VersionsHash: planPackage.VersionsHash}, nil
+}
+
+// Determines a sha256 hash of the ids, names, last updates and tags of versions, independent of their order
+func determineVersionsHash(versions *[]github_model.Version) string {
+	entries := make([]string, 0, len(*versions))
+	for _, v := range *versions {
+		entries = append(entries, fmt.Sprintf("%d|%s|%s|%s", v.Id, v.Name, v.UpdatedAt, strings.Join(getTags(&v), ",")))
+	}
+	slices.Sort(entries)
+	hash := sha256.Sum256([]byte(strings.Join(entries, "\n")))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ma-vin/packages-action/config"
+	"github.com/ma-vin/packages-action/service/github_model"
+	"github.com/ma-vin/testutil-go"
+)
+
+var planConf config.Config
+var planVersions []github_model.Version
+
+func initPlanTest(t *testing.T) {
+	initDeletionTest()
+	planConf = config.Config{User: "DummyUser", PackageType: config.MAVEN, PlanFile: filepath.Join(t.TempDir(), "plan.json"), MaxConcurrentDeletions: 1}
+	planVersions = []github_model.Version{
+		{Id: 2, Name: "1.0.0", CreatedAt: "2024-03-12T20:00:00Z", UpdatedAt: "2024-03-13T16:00:00Z"},
+		{Id: 3, Name: "1.1.0", CreatedAt: "2024-03-13T20:00:00Z", UpdatedAt: "2024-03-14T16:00:00Z"},
+	}
+
+	VersionsGetExecutor = func(ctx context.Context, config *config.Config) (*[]github_model.Version, error) {
+		return &planVersions, nil
+	}
+	CurrentTimeExecutor = func() time.Time {
+		return time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+	}
+}
+
+func createTestPlanCandidates() *[]PackageCandidates {
+	return &[]PackageCandidates{{PackageName: "DummyPackage", Candidates: &[]Candidate{{Id: 2, Name: "1.0.0", Type: VERSION_CANDIDATE,
+		MatchedRules: []MatchedRule{{RULE_VERSION_NAME, "version name is '1.0.0'"}}}}, KeptVersions: []string{"1.1.0"}, VersionsHash: determineVersionsHash(&planVersions)}}
+}
+
+func TestWritePlanWithoutFile(t *testing.T) {
+	initPlanTest(t)
+	planConf.PlanFile = ""
+
+	err := WritePlan(createTestPlanCandidates(), &planConf)
+
+	testutil.AssertNil(err, t, "err")
+}
+
+func TestWriteAndReadPlan(t *testing.T) {
+	initPlanTest(t)
+
+	err := WritePlan(createTestPlanCandidates(), &planConf)
+	testutil.AssertNil(err, t, "err write")
+
+	plan, err := ReadPlan(planConf.PlanFile)
+
+	testutil.AssertNil(err, t, "err read")
+	testutil.AssertNotNil(plan, t, "plan")
+	testutil.AssertEquals("2024-04-01T12:00:00Z", plan.CreatedAt, t, "created at")
+	testutil.AssertEquals("DummyUser", plan.Owner, t, "owner")
+	testutil.AssertEquals(config.MAVEN, plan.PackageType, t, "package type")
+	testutil.AssertEquals(1, len(plan.Packages), t, "len packages")
+	testutil.AssertEquals("DummyPackage", plan.Packages[0].Package, t, "package")
+	testutil.AssertEquals(determineVersionsHash(&planVersions), plan.Packages[0].VersionsHash, t, "versions hash")
+	testutil.AssertEquals(1, len(plan.Packages[0].Candidates), t, "len candidates")
+	testutil.AssertEquals(2, plan.Packages[0].Candidates[0].Id, t, "candidate id")
+	testutil.AssertEquals("version", plan.Packages[0].Candidates[0].Type, t, "candidate type")
+	testutil.AssertEquals(RULE_VERSION_NAME, plan.Packages[0].Candidates[0].MatchedRules[0].Rule, t, "candidate rule")
+	testutil.AssertEquals("1.1.0", plan.Packages[0].KeptVersions[0], t, "kept version")
+}
+
+func TestReadPlanInvalid(t *testing.T) {
+	initPlanTest(t)
+	os.WriteFile(planConf.PlanFile, []byte("no json"), 0644)
+
+	plan, err := ReadPlan(planConf.PlanFile)
+
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertNil(plan, t, "plan")
+}
+
+func TestDetermineVersionsHash(t *testing.T) {
+	initPlanTest(t)
+	hash := determineVersionsHash(&planVersions)
+
+	reversed := []github_model.Version{planVersions[1], planVersions[0]}
+	testutil.AssertEquals(hash, determineVersionsHash(&reversed), t, "hash independent of order")
+
+	added := append([]github_model.Version{{Id: 4, Name: "1.2.0"}}, planVersions...)
+	testutil.AssertFalse(hash == determineVersionsHash(&added), t, "hash of added version")
+
+	updated := []github_model.Version{planVersions[0], planVersions[1]}
+	updated[1].UpdatedAt = "2024-03-15T16:00:00Z"
+	testutil.AssertFalse(hash == determineVersionsHash(&updated), t, "hash of updated version")
+}
+
+func TestApplyPlan(t *testing.T) {
+	initPlanTest(t)
+	WritePlan(createTestPlanCandidates(), &planConf)
+
+	err := ApplyPlan(context.Background(), &planConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertEquals(0, countGetCandidatesExecuted, t, "get candidates executed")
+	testutil.AssertEquals(1, countDeleteVersionExecuted, t, "delete version executed")
+	testutil.AssertEquals(0, countDeletePackageExecuted, t, "delete package executed")
+	testutil.AssertEquals(1, len(writtenReport.Entries), t, "len report entries")
+	testutil.AssertEquals(2, writtenReport.Entries[0].Id, t, "report entry id")
+	testutil.AssertEquals(RESULT_DELETED, writtenReport.Entries[0].Result, t, "report entry result")
+}
+
+func TestApplyPlanDryRun(t *testing.T) {
+	initPlanTest(t)
+	WritePlan(createTestPlanCandidates(), &planConf)
+	planConf.DryRun = true
+
+	err := ApplyPlan(context.Background(), &planConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertEquals(0, countDeleteVersionExecuted, t, "delete version executed")
+	testutil.AssertEquals(RESULT_DRY_RUN, writtenReport.Entries[0].Result, t, "report entry result")
+}
+
+func TestApplyPlanDrifted(t *testing.T) {
+	initPlanTest(t)
+	WritePlan(createTestPlanCandidates(), &planConf)
+	planVersions = append(planVersions, github_model.Version{Id: 4, Name: "1.2.0"})
+
+	err := ApplyPlan(context.Background(), &planConf)
+
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertTrue(strings.Contains(err.Error(), "the versions of packages DummyPackage changed"), t, "error message")
+	testutil.AssertEquals(0, countDeleteVersionExecuted, t, "delete version executed")
+}
+
+func TestApplyPlanUnknownCandidateType(t *testing.T) {
+	initPlanTest(t)
+	WritePlan(createTestPlanCandidates(), &planConf)
+	content, err := os.ReadFile(planConf.PlanFile)
+	testutil.AssertNil(err, t, "err read plan")
+	os.WriteFile(planConf.PlanFile, []byte(strings.Replace(string(content), `"type": "version"`, `"type": "Package"`, 1)), 0644)
+
+	err = ApplyPlan(context.Background(), &planConf)
+
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertTrue(strings.Contains(err.Error(), "the candidate 1.0.0 with id 2 of package DummyPackage has the unknown type 'Package'"), t, "error message")
+	testutil.AssertEquals(0, countDeleteVersionExecuted, t, "delete version executed")
+	testutil.AssertEquals(0, countDeletePackageExecuted, t, "delete package executed")
+}
+
+func TestApplyPlanOtherOwner(t *testing.T) {
+	initPlanTest(t)
+	WritePlan(createTestPlanCandidates(), &planConf)
+	planConf.User = ""
+	planConf.Organization = "DummyOrganization"
+
+	err := ApplyPlan(context.Background(), &planConf)
+
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertEquals(0, countDeleteVersionExecuted, t, "delete version executed")
+}
+
+func TestApplyPlanMissingFile(t *testing.T) {
+	initPlanTest(t)
+
+	err := ApplyPlan(context.Background(), &planConf)
+
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertEquals(0, countDeleteVersionExecuted, t, "delete version executed")
+}
+
+func TestDeleteVersionsWritesPlan(t *testing.T) {
+	initPlanTest(t)
+	deletionCandidates = &[]Candidate{deletionVersionCandidate}
+	planConf.DryRun = true
+	PlanExecutor = initPlanExecutor()
+
+	err := DeleteVersions(context.Background(), &planConf)
+
+	testutil.AssertNil(err, t, "err")
+	plan, err := ReadPlan(planConf.PlanFile)
+	testutil.AssertNil(err, t, "err read")
+	testutil.AssertEquals(1, len(plan.Packages[0].Candidates), t, "len candidates")
+	testutil.AssertEquals(0, countDeleteVersionExecuted, t, "delete version executed")
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ma-vin/packages-action/config"
+	"github.com/ma-vin/typewriter/logger"
+)
+
+// scope of classic tokens which is required to delete versions and packages
+const delete_packages_scope string = "delete:packages"
+
+// Looks up the scopes of the token by accessing a package. The second result indicates whether the token has scopes at all
+type TokenScopesLookup func(ctx context.Context, packageName string, configuration *config.Config) ([]string, bool, error)
+
+// Checks before any deletion that the token can access each package and, if it is a classic token, that it has the scope to delete packages.
+// The permissions of other tokens, like installation tokens, can not be inspected, so only their access to the packages is checked
+func checkPermissions(ctx context.Context, packageNames []string, configuration *config.Config, scopesLookup TokenScopesLookup) error {
+	inspected := false
+	for _, packageName := range packageNames {
+		scopes, hasScopes, err := scopesLookup(ctx, packageName, configuration)
+		if err != nil {
+			return fmt.Errorf("preflight check failed: the token can not access package %s: %w", packageName, err)
+		}
+		if !hasScopes {
+			continue
+		}
+		inspected = true
+		if !slices.Contains(scopes, delete_packages_scope) {
+			return fmt.Errorf("preflight check failed: the token lacks the scope %s which is required to delete versions of package %s, granted scopes: %s",
+				delete_packages_scope, packageName, strings.Join(scopes, ", "))
+		}
+	}
+	if !inspected && len(packageNames) > 0 {
+		logger.Information("preflight check: the permissions of the token can not be inspected, only its access to the packages was checked")
+	}
+	return nil
+}
+
+// determines the names of the packages with any candidate
+func determinePackagesToDelete(packageCandidates *[]PackageCandidates) []string {
+	var res []string
+	for _, pc := range *packageCandidates {
+		if len(*pc.Candidates) > 0 {
+			res = append(res, pc.PackageName)
+		}
+	}
+	return res
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/ma-vin/packages-action/config"
+	"github.com/ma-vin/testutil-go"
+)
+
+var preflightConf = config.Config{User: "DummyUser", PackageType: "maven"}
+var lookedUpPackageNames []string
+
+func createScopesLookup(scopes []string, hasScopes bool, err error) TokenScopesLookup {
+	lookedUpPackageNames = []string{}
+	return func(ctx context.Context, packageName string, configuration *config.Config) ([]string, bool, error) {
+		lookedUpPackageNames = append(lookedUpPackageNames, packageName)
+		return scopes, hasScopes, err
+	}
+}
+
+func TestCheckPermissionsWithScope(t *testing.T) {
+	lookup := createScopesLookup([]string{"read:packages", "delete:packages"}, true, nil)
+
+	err := checkPermissions(context.Background(), []string{"DummyPackage", "OtherPackage"}, &preflightConf, lookup)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertEquals(2, len(lookedUpPackageNames), t, "number of looked up packages")
+}
+
+func TestCheckPermissionsClassicTokenMissingScope(t *testing.T) {
+	lookup := createScopesLookup([]string{"read:packages", "write:packages"}, true, nil)
+
+	err := checkPermissions(context.Background(), []string{"DummyPackage", "OtherPackage"}, &preflightConf, lookup)
+
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertEquals("preflight check failed: the token lacks the scope delete:packages which is required to delete versions of package DummyPackage, granted scopes: read:packages, write:packages",
+		err.Error(), t, "error message")
+	testutil.AssertEquals(1, len(lookedUpPackageNames), t, "number of looked up packages")
+}
+
+func TestCheckPermissionsWithoutScopesHeader(t *testing.T) {
+	lookup := createScopesLookup(nil, false, nil)
+
+	err := checkPermissions(context.Background(), []string{"DummyPackage", "OtherPackage"}, &preflightConf, lookup)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertEquals(2, len(lookedUpPackageNames), t, "number of looked up packages")
+}
+
+func TestCheckPermissionsAccessError(t *testing.T) {
+	lookup := createScopesLookup(nil, false, errors.New("an error status code occured: 404 - Not Found"))
+
+	err := checkPermissions(context.Background(), []string{"DummyPackage"}, &preflightConf, lookup)
+
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertEquals("preflight check failed: the token can not access package DummyPackage: an error status code occured: 404 - Not Found", err.Error(), t, "error message")
+}
+
+func TestGitHubRestClientCheckPermissionsForbidden(t *testing.T) {
+	client := NewGitHubRestClient()
+	client.ClientExecutor = func(c *http.Client, req *http.Request) (*http.Response, error) {
+		checkGetRequest(req, "https://api.github.com/users/DummyUser/packages/maven/DummyPackage", t)
+		body := `{"message": "Forbidden"}`
+		return createResponse(&body, 403), nil
+	}
+
+	err := client.CheckPermissions(context.Background(), []string{"DummyPackage"}, &restConf)
+
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertEquals("preflight check failed: the token can not access package DummyPackage: an error status code occured: 403 - Forbidden", err.Error(), t, "error message")
+}
+
+func TestGitHubRestClientCheckPermissionsMissingScope(t *testing.T) {
+	client := NewGitHubRestClient()
+	client.ClientExecutor = func(c *http.Client, req *http.Request) (*http.Response, error) {
+		res := createDefaultPackageResponse()
+		res.Header = http.Header{}
+		res.Header.Set("X-OAuth-Scopes", "read:packages")
+		return res, nil
+	}
+
+	err := client.CheckPermissions(context.Background(), []string{"DummyPackage"}, &restConf)
+
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertEquals("preflight check failed: the token lacks the scope delete:packages which is required to delete versions of package DummyPackage, granted scopes: read:packages",
+		err.Error(), t, "error message")
+}
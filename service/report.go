@@ -0,0 +1,181 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ma-vin/packages-action/config"
+	"github.com/ma-vin/typewriter/logger"
+)
+
+const (
+	// result of a successful deletion
+	RESULT_DELETED string = "deleted"
+	// result of a failed deletion
+	RESULT_FAILED string = "failed"
+	// result of a candidate which is not deleted because of dry run
+	RESULT_DRY_RUN string = "dry-run"
+	// result of a candidate which is not deleted because the deletion was aborted
+	RESULT_ABORTED string = "aborted"
+	// result of a candidate which is not deleted because the run was cancelled or exceeded its deadline
+	RESULT_CANCELLED string = "cancelled"
+	// result of a candidate whose deletion was interrupted by the cancellation of the run. It may be deleted at GitHub or not
+	RESULT_UNKNOWN string = "unknown"
+)
+
+// Report of all candidates and the results of their deletion
+type Report struct {
+	DryRun        bool                `json:"dry_run"`
+	Entries       []ReportEntry       `json:"entries"`
+	EmptyPackages []EmptyPackageEntry `json:"empty_packages,omitempty"`
+}
+
+// Entry of a report for a candidate
+type ReportEntry struct {
+	Package      string        `json:"package"`
+	Id           int           `json:"id"`
+	Name         string        `json:"name"`
+	Type         string        `json:"type"`
+	Reason       string        `json:"reason"`
+	MatchedRules []MatchedRule `json:"matched_rules,omitempty"`
+	Result       string        `json:"result"`
+	Error        string        `json:"error,omitempty"`
+}
+
+// Entry of a report for a package whose versions were all to delete and the applied empty package policy
+type EmptyPackageEntry struct {
+	Package     string `json:"package"`
+	Policy      string `json:"policy"`
+	KeptVersion string `json:"kept_version,omitempty"`
+}
+
+type ReportWriter func(report *Report, config *config.Config) error
+
+var ReportExecutor ReportWriter = initReportExecutor()
+
+func initReportExecutor() ReportWriter {
+	return func(report *Report, config *config.Config) error {
+		return WriteReport(report, config)
+	}
+}
+
+// Creates the report of the tasks and the results of their deletion. Without results, the tasks are reported as dry run
+func createReport(tasks []deletionTask, results []deletionResult, config *config.Config) *Report {
+	report := Report{DryRun: config.DryRun, Entries: make([]ReportEntry, 0, len(tasks))}
+	for i, task := range tasks {
+		entry := ReportEntry{Package: task.packageName, Id: task.candidate.Id, Name: task.candidate.Name,
+			Type: getCandidateTypeText(&task.candidate.Type), Reason: getMatchedRulesText(task.candidate.MatchedRules), MatchedRules: task.candidate.MatchedRules,
+			Result: RESULT_DRY_RUN}
+		if i < len(results) {
+			entry.Result = RESULT_DELETED
+			if results[i].cancelled {
+				entry.Result = RESULT_CANCELLED
+				entry.Error = results[i].err.Error()
+			} else if results[i].interrupted {
+				entry.Result = RESULT_UNKNOWN
+				entry.Error = results[i].err.Error()
+			} else if results[i].err != nil {
+				entry.Result = RESULT_FAILED
+				entry.Error = results[i].err.Error()
+			}
+		}
+		report.Entries = append(report.Entries, entry)
+	}
+	return &report
+}
+
+// Adds the packages whose versions were all to delete with the applied empty package policy to the report
+func addEmptyPackages(report *Report, packageCandidates *[]PackageCandidates) {
+	for _, pc := range *packageCandidates {
+		if pc.EmptyPackagePolicy == "" {
+			continue
+		}
+		entry := EmptyPackageEntry{Package: pc.PackageName, Policy: pc.EmptyPackagePolicy}
+		if pc.EmptyPackagePolicy == config.EMPTY_PACKAGE_KEEP_LATEST && len(pc.KeptVersions) > 0 {
+			entry.KeptVersion = pc.KeptVersions[len(pc.KeptVersions)-1]
+		}
+		report.EmptyPackages = append(report.EmptyPackages, entry)
+	}
+}
+
+// Marks all entries of the report, which are not deleted, as aborted
+func markReportAborted(report *Report) {
+	for i := range report.Entries {
+		if report.Entries[i].Result != RESULT_DELETED {
+			report.Entries[i].Result = RESULT_ABORTED
+		}
+	}
+}
+
+// Writes the report as json to the configured report file and appends it as markdown table to the configured step summary file.
+// Files which are not configured are skipped
+func WriteReport(report *Report, config *config.Config) error {
+	if config.ReportFile != "" {
+		content, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err = os.WriteFile(config.ReportFile, content, 0644); err != nil {
+			return err
+		}
+		logger.Informationf("Report written to %s", config.ReportFile)
+	}
+	if config.StepSummaryFile != "" {
+		file, err := os.OpenFile(config.StepSummaryFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		if _, err = file.WriteString(createMarkdownReport(report)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reads the json report of a previous run from a file
+func ReadReport(reportFile string) (*Report, error) {
+	content, err := os.ReadFile(reportFile)
+	if err != nil {
+		return nil, err
+	}
+	var report Report
+	if err = json.Unmarshal(content, &report); err != nil {
+		return nil, fmt.Errorf("the report %s is not valid: %w", reportFile, err)
+	}
+	return &report, nil
+}
+
+// Creates a markdown text with a table of the report entries
+func createMarkdownReport(report *Report) string {
+	var sb strings.Builder
+	sb.WriteString("### Packages action report\n\n")
+	if report.DryRun {
+		sb.WriteString("Dry run: nothing was deleted\n\n")
+	}
+	for _, e := range report.EmptyPackages {
+		sb.WriteString(fmt.Sprintf("All versions of package %s were to delete: empty package policy %s", escapeMarkdownCell(e.Package), e.Policy))
+		if e.KeptVersion != "" {
+			sb.WriteString(fmt.Sprintf(" keeps version %s", escapeMarkdownCell(e.KeptVersion)))
+		}
+		sb.WriteString("\n\n")
+	}
+	if len(report.Entries) == 0 {
+		sb.WriteString("No candidates determined\n")
+		return sb.String()
+	}
+	sb.WriteString("| Package | Type | Name | Id | Reason | Result | Error |\n")
+	sb.WriteString("|---|---|---|---|---|---|---|\n")
+	for _, e := range report.Entries {
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %d | %s | %s | %s |\n", escapeMarkdownCell(e.Package), e.Type, escapeMarkdownCell(e.Name), e.Id,
+			escapeMarkdownCell(e.Reason), e.Result, escapeMarkdownCell(e.Error)))
+	}
+	return sb.String()
+}
+
+// escapes characters which would break a markdown table cell
+func escapeMarkdownCell(text string) string {
+	return strings.NewReplacer("|", "\\|", "\r\n", " ", "\n", " ").Replace(text)
+}
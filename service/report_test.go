@@ -0,0 +1,128 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ma-vin/packages-action/config"
+	"github.com/ma-vin/testutil-go"
+)
+
+func createReportTestTasks() []deletionTask {
+	return []deletionTask{
+		{0, "DummyPackage", Candidate{Id: 2, Name: "1.0.0", Type: VERSION_CANDIDATE, MatchedRules: []MatchedRule{{RULE_MAJOR_TO_KEEP, "2 newer releases with a major version greater than 1"}, {RULE_VERSION_PATTERN, "'1.0.0' matches pattern '1.*'"}}}},
+		{1, "OtherPackage", Candidate{Id: 5, Name: "OtherPackage", Type: PACKAGE_CANDIDATE, MatchedRules: []MatchedRule{{RULE_ALL_VERSIONS, "all versions of the package are to delete"}}}},
+	}
+}
+
+func TestCreateReportDryRun(t *testing.T) {
+	report := createReport(createReportTestTasks(), nil, &config.Config{DryRun: true})
+
+	testutil.AssertTrue(report.DryRun, t, "dry run")
+	testutil.AssertEquals(2, len(report.Entries), t, "number of entries")
+	testutil.AssertEquals("DummyPackage", report.Entries[0].Package, t, "package of first entry")
+	testutil.AssertEquals(2, report.Entries[0].Id, t, "id of first entry")
+	testutil.AssertEquals("1.0.0", report.Entries[0].Name, t, "name of first entry")
+	testutil.AssertEquals("version", report.Entries[0].Type, t, "type of first entry")
+	testutil.AssertEquals(RESULT_DRY_RUN, report.Entries[0].Result, t, "result of first entry")
+	testutil.AssertEquals("major-to-keep (2 newer releases with a major version greater than 1), version-pattern ('1.0.0' matches pattern '1.*')", report.Entries[0].Reason, t, "reason of first entry")
+	testutil.AssertEquals(2, len(report.Entries[0].MatchedRules), t, "number of matched rules of first entry")
+	testutil.AssertEquals("package", report.Entries[1].Type, t, "type of second entry")
+	testutil.AssertEquals(RESULT_DRY_RUN, report.Entries[1].Result, t, "result of second entry")
+}
+
+func TestCreateReportWithResults(t *testing.T) {
+	tasks := createReportTestTasks()
+	results := []deletionResult{{tasks[0], nil, false, false}, {tasks[1], errors.New("testError"), false, false}}
+
+	report := createReport(tasks, results, &config.Config{DryRun: false})
+
+	testutil.AssertFalse(report.DryRun, t, "dry run")
+	testutil.AssertEquals(2, len(report.Entries), t, "number of entries")
+	testutil.AssertEquals(RESULT_DELETED, report.Entries[0].Result, t, "result of first entry")
+	testutil.AssertEquals("", report.Entries[0].Error, t, "error of first entry")
+	testutil.AssertEquals(RESULT_FAILED, report.Entries[1].Result, t, "result of second entry")
+	testutil.AssertEquals("testError", report.Entries[1].Error, t, "error of second entry")
+}
+
+func TestCreateReportEmptyPackages(t *testing.T) {
+	tasks := createReportTestTasks()
+	packageCandidates := []PackageCandidates{
+		{"DummyPackage", &[]Candidate{tasks[0].candidate}, []string{"1.0.1"}, config.EMPTY_PACKAGE_KEEP_LATEST, ""},
+		{"OtherPackage", &[]Candidate{tasks[1].candidate}, []string{}, config.EMPTY_PACKAGE_FAIL, ""},
+		{"ThirdPackage", &[]Candidate{}, []string{"3.0.0"}, "", ""},
+	}
+
+	report := createReport(tasks, nil, &config.Config{})
+	addEmptyPackages(report, &packageCandidates)
+	markReportAborted(report)
+
+	testutil.AssertEquals(2, len(report.EmptyPackages), t, "number of empty packages")
+	testutil.AssertEquals("DummyPackage", report.EmptyPackages[0].Package, t, "package of first empty package")
+	testutil.AssertEquals(config.EMPTY_PACKAGE_KEEP_LATEST, report.EmptyPackages[0].Policy, t, "policy of first empty package")
+	testutil.AssertEquals("1.0.1", report.EmptyPackages[0].KeptVersion, t, "kept version of first empty package")
+	testutil.AssertEquals(config.EMPTY_PACKAGE_FAIL, report.EmptyPackages[1].Policy, t, "policy of second empty package")
+	testutil.AssertEquals("", report.EmptyPackages[1].KeptVersion, t, "kept version of second empty package")
+	testutil.AssertEquals(RESULT_ABORTED, report.Entries[0].Result, t, "result of first entry")
+	testutil.AssertEquals(RESULT_ABORTED, report.Entries[1].Result, t, "result of second entry")
+
+	summary := createMarkdownReport(report)
+	testutil.AssertTrue(strings.Contains(summary, "All versions of package DummyPackage were to delete: empty package policy keep-latest keeps version 1.0.1\n"), t, "keep latest at summary")
+	testutil.AssertTrue(strings.Contains(summary, "All versions of package OtherPackage were to delete: empty package policy fail\n"), t, "fail at summary")
+}
+
+func TestWriteReport(t *testing.T) {
+	dir := t.TempDir()
+	reportConf := config.Config{ReportFile: filepath.Join(dir, "report.json"), StepSummaryFile: filepath.Join(dir, "summary.md")}
+	os.WriteFile(reportConf.StepSummaryFile, []byte("existing summary\n"), 0644)
+
+	tasks := createReportTestTasks()
+	tasks[0].candidate.Name = "1.0.0|a"
+	report := createReport(tasks, []deletionResult{{tasks[0], nil, false, false}, {tasks[1], errors.New("test\nError"), false, false}}, &reportConf)
+
+	err := WriteReport(report, &reportConf)
+	testutil.AssertNil(err, t, "err")
+
+	content, err := os.ReadFile(reportConf.ReportFile)
+	testutil.AssertNil(err, t, "err read report")
+	var readReport Report
+	err = json.Unmarshal(content, &readReport)
+	testutil.AssertNil(err, t, "err unmarshal report")
+	testutil.AssertEquals(2, len(readReport.Entries), t, "number of entries")
+	testutil.AssertEquals("1.0.0|a", readReport.Entries[0].Name, t, "name of first entry")
+	testutil.AssertEquals(RESULT_FAILED, readReport.Entries[1].Result, t, "result of second entry")
+	testutil.AssertEquals(1, len(readReport.Entries[1].MatchedRules), t, "number of matched rules of second entry")
+	testutil.AssertEquals(RULE_ALL_VERSIONS, readReport.Entries[1].MatchedRules[0].Rule, t, "matched rule of second entry")
+	testutil.AssertTrue(strings.Contains(string(content), `"error": "test\nError"`), t, "error at json")
+	testutil.AssertFalse(strings.Contains(string(content), `"error": ""`), t, "empty error omitted")
+
+	content, err = os.ReadFile(reportConf.StepSummaryFile)
+	testutil.AssertNil(err, t, "err read summary")
+	summary := string(content)
+	testutil.AssertTrue(strings.HasPrefix(summary, "existing summary\n### Packages action report"), t, "appended summary")
+	testutil.AssertTrue(strings.Contains(summary, "| DummyPackage | version | 1.0.0\\|a | 2 | major-to-keep (2 newer releases with a major version greater than 1), version-pattern ('1.0.0' matches pattern '1.*') | deleted |  |\n"), t, "first row")
+	testutil.AssertTrue(strings.Contains(summary, "| OtherPackage | package | OtherPackage | 5 | all-versions (all versions of the package are to delete) | failed | test Error |\n"), t, "second row")
+}
+
+func TestWriteReportEmptyDryRun(t *testing.T) {
+	dir := t.TempDir()
+	reportConf := config.Config{DryRun: true, StepSummaryFile: filepath.Join(dir, "summary.md")}
+
+	err := WriteReport(createReport(nil, nil, &reportConf), &reportConf)
+	testutil.AssertNil(err, t, "err")
+
+	content, err := os.ReadFile(reportConf.StepSummaryFile)
+	testutil.AssertNil(err, t, "err read summary")
+	testutil.AssertEquals("### Packages action report\n\nDry run: nothing was deleted\n\nNo candidates determined\n", string(content), t, "summary")
+}
+
+func TestWriteReportNotWritable(t *testing.T) {
+	reportConf := config.Config{ReportFile: filepath.Join(t.TempDir(), "missing", "report.json")}
+
+	err := WriteReport(createReport(nil, nil, &reportConf), &reportConf)
+	testutil.AssertNotNil(err, t, "err")
+}
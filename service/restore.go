@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/ma-vin/packages-action/config"
+	"github.com/ma-vin/packages-action/service/github_model"
+	"github.com/ma-vin/typewriter/logger"
+)
+
+type GitHubGetDeletedVersionsRestExecutor func(ctx context.Context, packageName string, config *config.Config) (*[]github_model.Version, error)
+type GitHubRestoreVersionRestExecutor func(ctx context.Context, packageName string, versionId int, config *config.Config) error
+type GitHubRestorePackageRestExecutor func(ctx context.Context, packageName string, config *config.Config) error
+
+// a deleted version or package which is to restore
+type restoreTask struct {
+	packageName string
+	id          int
+	name        string
+	taskType    int
+}
+
+var DeletedVersionsGetExecutor GitHubGetDeletedVersionsRestExecutor = initDeletedVersionsGetExecutor()
+var RestoreVersionExecutor GitHubRestoreVersionRestExecutor = initRestoreVersionExecutor()
+var RestorePackageExecutor GitHubRestorePackageRestExecutor = initRestorePackageExecutor()
+
+func initDeletedVersionsGetExecutor() GitHubGetDeletedVersionsRestExecutor {
+	return func(ctx context.Context, packageName string, config *config.Config) (*[]github_model.Version, error) {
+		return GetDeletedUserPackageVersions(ctx, packageName, config)
+	}
+}
+
+func initRestoreVersionExecutor() GitHubRestoreVersionRestExecutor {
+	return func(ctx context.Context, packageName string, versionId int, config *config.Config) error {
+		return RestoreUserPackageVersion(ctx, packageName, versionId, config)
+	}
+}
+
+func initRestorePackageExecutor() GitHubRestorePackageRestExecutor {
+	return func(ctx context.Context, packageName string, config *config.Config) error {
+		return RestoreUserPackage(ctx, packageName, config)
+	}
+}
+
+func InitAllRestore() {
+	DeletedVersionsGetExecutor = initDeletedVersionsGetExecutor()
+	RestoreVersionExecutor = initRestoreVersionExecutor()
+	RestorePackageExecutor = initRestorePackageExecutor()
+}
+
+// Restores the deleted versions and packages of the report of a previous run and the configured versions and packages.
+// Packages are restored before versions, since the versions of a deleted package can not be restored without it.
+// If the context is done, the remaining versions and packages are not restored anymore
+func RestoreVersions(ctx context.Context, config *config.Config) error {
+	tasks, err := createRestoreTasks(ctx, config)
+	if err != nil {
+		return err
+	}
+	if len(tasks) == 0 {
+		logger.Information("no versions or packages determined to restore")
+		return nil
+	}
+
+	logRestoreTasks(tasks)
+	if config.DryRun {
+		logger.Information("Skip restore because of dryRun")
+		return nil
+	}
+
+	withErrors := false
+	for i, task := range tasks {
+		if ctx.Err() != nil {
+			logger.Errorf("restore cancelled after %d of %d elements: %v", i, len(tasks), context.Cause(ctx))
+			return fmt.Errorf("restore cancelled: %w", context.Cause(ctx))
+		}
+		if err := restore(ctx, &task, config); err != nil {
+			withErrors = true
+			logger.Error(err.Error())
+			continue
+		}
+		logger.Informationf("restored %s '%s' with id %d of package %s", getCandidateTypeText(&task.taskType), task.name, task.id, task.packageName)
+	}
+	if withErrors {
+		return errors.New("restore execution with errors")
+	}
+	return nil
+}
+
+// creates the tasks to restore of the report file and of the configured packages. Packages are ordered before versions and duplicates are removed
+func createRestoreTasks(ctx context.Context, config *config.Config) ([]restoreTask, error) {
+	var tasks []restoreTask
+	if config.RestoreReportFile != "" {
+		report, err := ReadReport(config.RestoreReportFile)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, createRestoreTasksOfReport(report)...)
+	}
+
+	for _, packageName := range config.PackageNames {
+		if config.RestorePackage {
+			tasks = append(tasks, restoreTask{packageName: packageName, name: packageName, taskType: PACKAGE_CANDIDATE})
+		}
+		versionTasks, err := createRestoreTasksOfVersions(ctx, packageName, config)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, versionTasks...)
+	}
+
+	var res []restoreTask
+	for _, task := range tasks {
+		if !slices.ContainsFunc(res, func(t restoreTask) bool { return isSameRestoreTask(&t, &task) }) {
+			res = append(res, task)
+		}
+	}
+	slices.SortStableFunc(res, func(a restoreTask, b restoreTask) int {
+		return b.taskType - a.taskType
+	})
+	return res, nil
+}
+
+// creates the tasks to restore of all entries of a report which were deleted
+func createRestoreTasksOfReport(report *Report) []restoreTask {
+	packageType := PACKAGE_CANDIDATE
+	var tasks []restoreTask
+	for _, e := range report.Entries {
+		if e.Result != RESULT_DELETED {
+			continue
+		}
+		if e.Type == getCandidateTypeText(&packageType) {
+			tasks = append(tasks, restoreTask{packageName: e.Package, id: e.Id, name: e.Name, taskType: PACKAGE_CANDIDATE})
+		} else {
+			tasks = append(tasks, restoreTask{packageName: e.Package, id: e.Id, name: e.Name, taskType: VERSION_CANDIDATE})
+		}
+	}
+	return tasks
+}
+
+// Creates the tasks to restore of the configured versions of a package. The versions are given by their ids or names and are resolved against
+// the deleted versions of the package. Names which can not be resolved are skipped, ids are restored anyway
+func createRestoreTasksOfVersions(ctx context.Context, packageName string, config *config.Config) ([]restoreTask, error) {
+	if len(config.RestoreVersions) == 0 {
+		return nil, nil
+	}
+	deletedVersions, err := DeletedVersionsGetExecutor(ctx, packageName, config)
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []restoreTask
+	for _, versionToRestore := range config.RestoreVersions {
+		id, idErr := strconv.Atoi(versionToRestore)
+		index := slices.IndexFunc(*deletedVersions, func(v github_model.Version) bool {
+			return v.Name == versionToRestore || (idErr == nil && v.Id == id)
+		})
+		switch {
+		case index >= 0:
+			tasks = append(tasks, restoreTask{packageName: packageName, id: (*deletedVersions)[index].Id, name: (*deletedVersions)[index].Name, taskType: VERSION_CANDIDATE})
+		case idErr == nil:
+			tasks = append(tasks, restoreTask{packageName: packageName, id: id, name: versionToRestore, taskType: VERSION_CANDIDATE})
+		default:
+			logger.Warningf("There does not exists a deleted version with name %s of package %s: skip restore", versionToRestore, packageName)
+		}
+	}
+	return tasks, nil
+}
+
+// checks whether two tasks restore the same version or package
+func isSameRestoreTask(a *restoreTask, b *restoreTask) bool {
+	if a.taskType != b.taskType || a.packageName != b.packageName {
+		return false
+	}
+	return a.taskType == PACKAGE_CANDIDATE || a.id == b.id
+}
+
+// executes the restore of a version or package
+func restore(ctx context.Context, task *restoreTask, config *config.Config) error {
+	switch task.taskType {
+	case VERSION_CANDIDATE:
+		return RestoreVersionExecutor(ctx, task.packageName, task.id, config)
+	case PACKAGE_CANDIDATE:
+		return RestorePackageExecutor(ctx, task.packageName, config)
+	default:
+		return fmt.Errorf("cannot restore '%s' with id %d of unknown type", task.name, task.id)
+	}
+}
+
+// logs the versions and packages which will be restored
+func logRestoreTasks(tasks []restoreTask) {
+	logger.Information("the following elements will be restored")
+	for i, task := range tasks {
+		logger.Informationf("  %d. type: %s package: %s name: '%s' id: %d", i+1, getCandidateTypeText(&task.taskType), task.packageName, task.name, task.id)
+	}
+}
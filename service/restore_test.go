@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ma-vin/packages-action/config"
+	"github.com/ma-vin/packages-action/service/github_model"
+	"github.com/ma-vin/testutil-go"
+)
+
+var restoreConf config.Config
+var deletedVersions *[]github_model.Version
+var deletedVersionsError error
+var restoreVersionError error
+var restorePackageError error
+var restoredVersionIds []int
+var restoredPackageNames []string
+var restoreOrder []string
+
+func initRestoreTest() {
+	restoreConf = config.Config{Mode: config.MODE_RESTORE, PackageNames: []string{"DummyPackage"}}
+	deletedVersions = &[]github_model.Version{
+		{Id: 2, Name: "1.0.0-SNAPSHOT", DeletedAt: "2024-03-20T20:00:00Z"},
+		{Id: 3, Name: "2.0.0-SNAPSHOT", DeletedAt: "2024-03-20T20:00:00Z"},
+	}
+	deletedVersionsError = nil
+	restoreVersionError = nil
+	restorePackageError = nil
+	restoredVersionIds = nil
+	restoredPackageNames = nil
+	restoreOrder = nil
+
+	DeletedVersionsGetExecutor = func(ctx context.Context, packageName string, config *config.Config) (*[]github_model.Version, error) {
+		return deletedVersions, deletedVersionsError
+	}
+	RestoreVersionExecutor = func(ctx context.Context, packageName string, versionId int, config *config.Config) error {
+		restoredVersionIds = append(restoredVersionIds, versionId)
+		restoreOrder = append(restoreOrder, "version")
+		return restoreVersionError
+	}
+	RestorePackageExecutor = func(ctx context.Context, packageName string, config *config.Config) error {
+		restoredPackageNames = append(restoredPackageNames, packageName)
+		restoreOrder = append(restoreOrder, "package")
+		return restorePackageError
+	}
+}
+
+func writeRestoreTestReport(t *testing.T) string {
+	reportFile := filepath.Join(t.TempDir(), "report.json")
+	tasks := []deletionTask{
+		{0, "DummyPackage", Candidate{Id: 2, Name: "1.0.0-SNAPSHOT", Type: VERSION_CANDIDATE}},
+		{1, "DummyPackage", Candidate{Id: 4, Name: "3.0.0-SNAPSHOT", Type: VERSION_CANDIDATE}},
+		{2, "OtherPackage", Candidate{Id: 5, Name: "OtherPackage", Type: PACKAGE_CANDIDATE}},
+	}
+	results := []deletionResult{{tasks[0], nil, false, false}, {tasks[1], errors.New("testError"), false, false}, {tasks[2], nil, false, false}}
+	reportConf := config.Config{ReportFile: reportFile}
+	WriteReport(createReport(tasks, results, &reportConf), &reportConf)
+	return reportFile
+}
+
+func TestRestoreVersionsByNameAndId(t *testing.T) {
+	initRestoreTest()
+
+	restoreConf.RestoreVersions = []string{"2.0.0-SNAPSHOT", "2", "7", "9.9.9"}
+
+	err := RestoreVersions(context.Background(), &restoreConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertEquals(3, len(restoredVersionIds), t, "len restored versions")
+	testutil.AssertEquals(3, restoredVersionIds[0], t, "first restored version")
+	testutil.AssertEquals(2, restoredVersionIds[1], t, "second restored version")
+	testutil.AssertEquals(7, restoredVersionIds[2], t, "third restored version")
+	testutil.AssertEquals(0, len(restoredPackageNames), t, "len restored packages")
+}
+
+func TestRestoreVersionsFromReport(t *testing.T) {
+	initRestoreTest()
+
+	restoreConf.PackageNames = []string{}
+	restoreConf.RestoreReportFile = writeRestoreTestReport(t)
+
+	err := RestoreVersions(context.Background(), &restoreConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertEquals(1, len(restoredVersionIds), t, "len restored versions")
+	testutil.AssertEquals(2, restoredVersionIds[0], t, "restored version")
+	testutil.AssertEquals(1, len(restoredPackageNames), t, "len restored packages")
+	testutil.AssertEquals("OtherPackage", restoredPackageNames[0], t, "restored package")
+	testutil.AssertEquals("package", restoreOrder[0], t, "package restored first")
+}
+
+func TestRestoreVersionsPackageAndDuplicates(t *testing.T) {
+	initRestoreTest()
+
+	restoreConf.RestoreReportFile = writeRestoreTestReport(t)
+	restoreConf.RestorePackage = true
+	restoreConf.RestoreVersions = []string{"1.0.0-SNAPSHOT"}
+
+	err := RestoreVersions(context.Background(), &restoreConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertEquals(1, len(restoredVersionIds), t, "len restored versions")
+	testutil.AssertEquals(2, len(restoredPackageNames), t, "len restored packages")
+	testutil.AssertEquals("OtherPackage", restoredPackageNames[0], t, "first restored package")
+	testutil.AssertEquals("DummyPackage", restoredPackageNames[1], t, "second restored package")
+	testutil.AssertEquals("version", restoreOrder[2], t, "version restored last")
+}
+
+func TestRestoreVersionsDryRun(t *testing.T) {
+	initRestoreTest()
+
+	restoreConf.DryRun = true
+	restoreConf.RestoreVersions = []string{"2"}
+
+	err := RestoreVersions(context.Background(), &restoreConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertEquals(0, len(restoredVersionIds), t, "len restored versions")
+}
+
+func TestRestoreVersionsCancelled(t *testing.T) {
+	initRestoreTest()
+
+	restoreConf.RestorePackage = true
+	restoreConf.RestoreVersions = []string{"2", "3"}
+	ctx, cancel := context.WithCancel(context.Background())
+	RestorePackageExecutor = func(ctx context.Context, packageName string, config *config.Config) error {
+		restoredPackageNames = append(restoredPackageNames, packageName)
+		cancel()
+		return nil
+	}
+
+	err := RestoreVersions(ctx, &restoreConf)
+
+	testutil.AssertTrue(errors.Is(err, context.Canceled), t, "cancelled error")
+	testutil.AssertEquals(1, len(restoredPackageNames), t, "len restored packages")
+	testutil.AssertEquals(0, len(restoredVersionIds), t, "len restored versions")
+}
+
+func TestRestoreVersionsWithRestoreError(t *testing.T) {
+	initRestoreTest()
+
+	restoreConf.RestoreVersions = []string{"2", "3"}
+	restoreVersionError = errors.New("testError")
+
+	err := RestoreVersions(context.Background(), &restoreConf)
+
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertEquals("restore execution with errors", err.Error(), t, "error message")
+	testutil.AssertEquals(2, len(restoredVersionIds), t, "len restore attempts")
+}
+
+func TestRestoreVersionsWithDeletedVersionsError(t *testing.T) {
+	initRestoreTest()
+
+	restoreConf.RestoreVersions = []string{"2"}
+	deletedVersionsError = errors.New("testError")
+
+	err := RestoreVersions(context.Background(), &restoreConf)
+
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertEquals(0, len(restoredVersionIds), t, "len restored versions")
+}
+
+func TestRestoreVersionsMissingReport(t *testing.T) {
+	initRestoreTest()
+
+	restoreConf.RestoreReportFile = filepath.Join(t.TempDir(), "missing.json")
+
+	err := RestoreVersions(context.Background(), &restoreConf)
+
+	testutil.AssertNotNil(err, t, "err")
+}
+
+func TestRestoreVersionsInvalidReport(t *testing.T) {
+	initRestoreTest()
+
+	restoreConf.RestoreReportFile = filepath.Join(t.TempDir(), "report.json")
+	os.WriteFile(restoreConf.RestoreReportFile, []byte("no json"), 0644)
+
+	err := RestoreVersions(context.Background(), &restoreConf)
+
+	testutil.AssertNotNil(err, t, "err")
+}
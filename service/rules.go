@@ -0,0 +1,91 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ma-vin/packages-action/config"
+	"github.com/ma-vin/packages-action/service/github_model"
+	"github.com/ma-vin/packages-action/service/version_model"
+)
+
+const (
+	RULE_VERSION_NAME     string = "version-name"
+	RULE_VERSION_PATTERN  string = "version-pattern"
+	RULE_SNAPSHOT         string = "snapshot"
+	RULE_SNAPSHOT_MAX_AGE string = "snapshot-max-age"
+	RULE_RELEASE_MAX_AGE  string = "release-max-age"
+	RULE_MAJOR_TO_KEEP    string = "major-to-keep"
+	RULE_MINOR_TO_KEEP    string = "minor-to-keep"
+	RULE_PATCH_TO_KEEP    string = "patch-to-keep"
+	RULE_UNTAGGED         string = "untagged"
+	RULE_TAG_PATTERN      string = "tag-pattern"
+	RULE_ALL_VERSIONS     string = "all-versions"
+)
+
+// A rule which matched a candidate together with the evidence why it matched
+type MatchedRule struct {
+	Rule     string `json:"rule"`
+	Evidence string `json:"evidence"`
+}
+
+// Determines the rules of the concrete version name and the version patterns to delete which match any of the names
+func determineNameRules(patterns *versionPatterns, config *config.Config, names ...string) []MatchedRule {
+	var rules []MatchedRule
+	if config.VersionNameToDelete != "" {
+		if i := slices.IndexFunc(names, func(name string) bool { return strings.EqualFold(name, config.VersionNameToDelete) }); i >= 0 {
+			rules = append(rules, MatchedRule{RULE_VERSION_NAME, fmt.Sprintf("'%s' is the version name to delete", names[i])})
+		}
+	}
+	if pattern, name, found := patterns.findToDelete(names...); found {
+		rules = append(rules, MatchedRule{RULE_VERSION_PATTERN, fmt.Sprintf("'%s' matches pattern '%s'", name, pattern)})
+	}
+	return rules
+}
+
+// Determines the rules of snapshots, age and number of versions to keep which match the version at index. The parsed version at index must not be nil
+func determineVersionRules(index *int, version *github_model.Version, parsedVersions *[]*version_model.Version, config *config.Config, now time.Time) []MatchedRule {
+	var rules []MatchedRule
+	parsedVersion := (*parsedVersions)[*index]
+	isIndexSnapshot := parsedVersion.IsSnapshot()
+
+	if config.DeleteSnapshots && isIndexSnapshot {
+		rules = append(rules, MatchedRule{RULE_SNAPSHOT, fmt.Sprintf("%s is a snapshot", parsedVersion.Name)})
+	}
+	if isSnapshotAgeDelete(version, parsedVersion, config, now) {
+		rules = append(rules, MatchedRule{RULE_SNAPSHOT_MAX_AGE, getAgeEvidence(version, config.MaxAgeOfSnapshots)})
+	}
+	if isReleaseAgeDelete(index, version, parsedVersions, config, now) {
+		rules = append(rules, MatchedRule{RULE_RELEASE_MAX_AGE, getAgeEvidence(version, config.MaxAgeOfReleases)})
+	}
+	if isIndexSnapshot {
+		return rules
+	}
+	if count := countCreaterMajorVersions(index, parsedVersions); config.NumberOfMajorVersionsToKeep > 0 && count >= config.NumberOfMajorVersionsToKeep {
+		rules = append(rules, MatchedRule{RULE_MAJOR_TO_KEEP, fmt.Sprintf("%d newer releases with a major version greater than %d", count, parsedVersion.Major())})
+	}
+	if count := countCreaterMinorVersions(index, parsedVersions); config.NumberOfMinorVersionsToKeep > 0 && count >= config.NumberOfMinorVersionsToKeep {
+		rules = append(rules, MatchedRule{RULE_MINOR_TO_KEEP, fmt.Sprintf("%d newer minor releases in %d.x", count, parsedVersion.Major())})
+	}
+	if count := countCreaterPatchVersions(index, parsedVersions); config.NumberOfPatchVersionsToKeep > 0 && count >= config.NumberOfPatchVersionsToKeep {
+		rules = append(rules, MatchedRule{RULE_PATCH_TO_KEEP, fmt.Sprintf("%d newer patch releases in %d.%d.x", count, parsedVersion.Major(), parsedVersion.Minor())})
+	}
+	return rules
+}
+
+// returns the evidence of an age rule
+func getAgeEvidence(version *github_model.Version, days int) string {
+	lastChange, _ := determineLastChange(version)
+	return fmt.Sprintf("last change at %s is more than %d days ago", lastChange.Format(time.RFC3339), days)
+}
+
+// returns the text of matched rules with their evidence
+func getMatchedRulesText(rules []MatchedRule) string {
+	texts := make([]string, len(rules))
+	for i, r := range rules {
+		texts[i] = fmt.Sprintf("%s (%s)", r.Rule, r.Evidence)
+	}
+	return strings.Join(texts, ", ")
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ma-vin/packages-action/config"
+	"github.com/ma-vin/testutil-go"
+)
+
+func TestMatchedRulesVersionNameAndPattern(t *testing.T) {
+	initCandidateTest()
+
+	candidatesConf.VersionNameToDelete = "1.1.0"
+	candidatesConf.VersionPatternsToDelete = []string{"1.1.0*"}
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertEquals(1, len(*candidates), t, "len candidates")
+	testutil.AssertEquals(3, (*candidates)[0].Id, t, "id")
+	rules := (*candidates)[0].MatchedRules
+	testutil.AssertEquals(2, len(rules), t, "len rules")
+	testutil.AssertEquals(RULE_VERSION_NAME, rules[0].Rule, t, "first rule")
+	testutil.AssertEquals("'1.1.0' is the version name to delete", rules[0].Evidence, t, "first evidence")
+	testutil.AssertEquals(RULE_VERSION_PATTERN, rules[1].Rule, t, "second rule")
+	testutil.AssertEquals("'1.1.0' matches pattern '1.1.0*'", rules[1].Evidence, t, "second evidence")
+}
+
+func TestMatchedRulesNumberToKeep(t *testing.T) {
+	initCandidateTest()
+
+	candidateVersionOne.Name = "1.0.0"
+	candidateVersionTwo.Name = "2.0.0"
+	candidateVersionThreee.Name = "2.0.1"
+	candidatesConf.NumberOfMajorVersionsToKeep = 1
+	candidatesConf.NumberOfPatchVersionsToKeep = 1
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertEquals(2, len(*candidates), t, "len candidates")
+	testutil.AssertEquals(1, len((*candidates)[0].MatchedRules), t, "len rules of first candidate")
+	testutil.AssertEquals(RULE_MAJOR_TO_KEEP, (*candidates)[0].MatchedRules[0].Rule, t, "rule of first candidate")
+	testutil.AssertEquals("2 newer releases with a major version greater than 1", (*candidates)[0].MatchedRules[0].Evidence, t, "evidence of first candidate")
+	testutil.AssertEquals(1, len((*candidates)[1].MatchedRules), t, "len rules of second candidate")
+	testutil.AssertEquals(RULE_PATCH_TO_KEEP, (*candidates)[1].MatchedRules[0].Rule, t, "rule of second candidate")
+	testutil.AssertEquals("1 newer patch releases in 2.0.x", (*candidates)[1].MatchedRules[0].Evidence, t, "evidence of second candidate")
+}
+
+func TestMatchedRulesMinor(t *testing.T) {
+	initCandidateTest()
+
+	candidatesConf.NumberOfMinorVersionsToKeep = 1
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertEquals(1, len(*candidates), t, "len candidates")
+	testutil.AssertEquals(RULE_MINOR_TO_KEEP, (*candidates)[0].MatchedRules[0].Rule, t, "rule")
+	testutil.AssertEquals("2 newer minor releases in 1.x", (*candidates)[0].MatchedRules[0].Evidence, t, "evidence")
+}
+
+func TestMatchedRulesSnapshotAndAge(t *testing.T) {
+	initCandidateTest()
+
+	candidateVersionOne.Name = "1.0.0-SNAPSHOT"
+	candidatesConf.DeleteSnapshots = true
+	candidatesConf.MaxAgeOfSnapshots = 14
+	candidatesConf.MaxAgeOfReleases = 17
+	candidatesConf.NumberOfNewestReleasesToKeep = 1
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertEquals(2, len(*candidates), t, "len candidates")
+	rules := (*candidates)[0].MatchedRules
+	testutil.AssertEquals(2, len(rules), t, "len rules of first candidate")
+	testutil.AssertEquals(RULE_SNAPSHOT, rules[0].Rule, t, "first rule of first candidate")
+	testutil.AssertEquals("1.0.0-SNAPSHOT is a snapshot", rules[0].Evidence, t, "first evidence of first candidate")
+	testutil.AssertEquals(RULE_SNAPSHOT_MAX_AGE, rules[1].Rule, t, "second rule of first candidate")
+	testutil.AssertEquals("last change at 2024-03-13T16:00:00Z is more than 14 days ago", rules[1].Evidence, t, "second evidence of first candidate")
+	rules = (*candidates)[1].MatchedRules
+	testutil.AssertEquals(1, len(rules), t, "len rules of second candidate")
+	testutil.AssertEquals(RULE_RELEASE_MAX_AGE, rules[0].Rule, t, "rule of second candidate")
+	testutil.AssertEquals("last change at 2024-03-14T16:00:00Z is more than 17 days ago", rules[0].Evidence, t, "evidence of second candidate")
+}
+
+func TestMatchedRulesPackage(t *testing.T) {
+	initCandidateTest()
+
+	candidatesConf.EmptyPackagePolicy = config.EMPTY_PACKAGE_DELETE_PACKAGE
+	candidatesConf.VersionPatternsToDelete = []string{"*"}
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertEquals(1, len(*candidates), t, "len candidates")
+	testutil.AssertEquals(PACKAGE_CANDIDATE, (*candidates)[0].Type, t, "type")
+	testutil.AssertEquals(1, len((*candidates)[0].MatchedRules), t, "len rules")
+	testutil.AssertEquals(RULE_ALL_VERSIONS, (*candidates)[0].MatchedRules[0].Rule, t, "rule")
+}
+
+func TestMatchedRulesContainer(t *testing.T) {
+	initContainerCandidateTest([]string{}, []string{"pr-1", "pr-2"}, []string{"2.0.0", "latest"})
+
+	candidatesConf.DeleteUntagged = true
+	candidatesConf.TagPatternToDelete = "^pr-"
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertEquals(2, len(*candidates), t, "len candidates")
+	testutil.AssertEquals(RULE_UNTAGGED, (*candidates)[0].MatchedRules[0].Rule, t, "rule of first candidate")
+	testutil.AssertEquals("version has no tags", (*candidates)[0].MatchedRules[0].Evidence, t, "evidence of first candidate")
+	testutil.AssertEquals(RULE_TAG_PATTERN, (*candidates)[1].MatchedRules[0].Rule, t, "rule of second candidate")
+	testutil.AssertEquals("all tags 'pr-1, pr-2' match '^pr-'", (*candidates)[1].MatchedRules[0].Evidence, t, "evidence of second candidate")
+}
+
+func TestMatchedRulesContainerTagName(t *testing.T) {
+	initContainerCandidateTest([]string{"1.0.0"}, []string{"1.1.0"}, []string{"2.0.0", "latest"})
+
+	candidatesConf.VersionNameToDelete = "1.1.0"
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertEquals(1, len(*candidates), t, "len candidates")
+	testutil.AssertEquals(RULE_VERSION_NAME, (*candidates)[0].MatchedRules[0].Rule, t, "rule")
+	testutil.AssertEquals("'1.1.0' is the version name to delete", (*candidates)[0].MatchedRules[0].Evidence, t, "evidence")
+}
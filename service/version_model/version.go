@@ -0,0 +1,349 @@
+package version_model
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// classification of a version qualifier. The order of the constants corresponds to the precedence of the qualifiers
+type QualifierKind int
+
+const (
+	ALPHA QualifierKind = iota
+	BETA
+	MILESTONE
+	RELEASE_CANDIDATE
+	SNAPSHOT
+	RELEASE
+	SERVICE_PACK
+	UNKNOWN
+)
+
+// model of a parsed version name, either as maven version or as semantic version (https://semver.org)
+type Version struct {
+	// the original name of the version
+	Name string
+	// major, minor, patch and further numbers of the version. Missing minor or patch numbers are set to zero
+	Numbers []int
+	// qualifier of a maven version or prerelease of a semantic version, both without leading separator
+	Qualifier string
+	// classification of the qualifier
+	QualifierKind QualifierKind
+	// number which follows the qualifier kind, e.g. 3 at beta.3 or 1 at RC1
+	QualifierNumber int
+	// build metadata which are not relevant for precedence
+	Build string
+	// indicator whether the version is parsed as semantic version or as maven version
+	Semantic bool
+}
+
+// Parses a name as maven version: numbers separated by dots, followed by an optional qualifier which is separated by
+// a hyphen or a dot, e.g. 1.2.3.4, 1.2.3-RC1, 1.2.3.RELEASE or 1.2-SNAPSHOT. A leading "v" and build metadata behind "+" are accepted
+func ParseMaven(name string) (*Version, error) {
+	rest, build, hasBuild := strings.Cut(trimVersionPrefix(name), "+")
+	if hasBuild && build == "" {
+		return nil, fmt.Errorf("there are empty build metadata at maven version name '%s'", name)
+	}
+	core, qualifier, hasQualifier := strings.Cut(rest, "-")
+	if hasQualifier && qualifier == "" {
+		return nil, fmt.Errorf("there is an empty qualifier at maven version name '%s'", name)
+	}
+
+	var numbers []int
+	parts := strings.Split(core, ".")
+	for i, part := range parts {
+		if isNumeric(part) {
+			number, err := strconv.Atoi(part)
+			if err != nil {
+				return nil, fmt.Errorf("failed to format number '%s' at maven version name '%s': %v", part, name, err)
+			}
+			numbers = append(numbers, number)
+			continue
+		}
+		if i == 0 || !startsWithLetter(part) {
+			return nil, fmt.Errorf("the element '%s' is neither a number nor a qualifier at maven version name '%s'", part, name)
+		}
+		dotQualifier := strings.Join(parts[i:], ".")
+		if hasQualifier {
+			dotQualifier += "-" + qualifier
+		}
+		qualifier = dotQualifier
+		break
+	}
+
+	result := Version{Name: name, Numbers: fillNumbers(numbers), Qualifier: qualifier, Build: build, Semantic: false}
+	result.QualifierKind, result.QualifierNumber = classifyQualifier(qualifier)
+	return &result, nil
+}
+
+// Parses a name as semantic version 2.0 (https://semver.org): major.minor.patch[-prerelease][+build]. A leading "v" is accepted
+func ParseSemantic(name string) (*Version, error) {
+	rest, build, hasBuild := strings.Cut(trimVersionPrefix(name), "+")
+	if hasBuild {
+		if err := checkIdentifiers(build, false); err != nil {
+			return nil, fmt.Errorf("invalid build metadata at semantic version name '%s': %v", name, err)
+		}
+	}
+	core, prerelease, hasPrerelease := strings.Cut(rest, "-")
+	if hasPrerelease {
+		if err := checkIdentifiers(prerelease, true); err != nil {
+			return nil, fmt.Errorf("invalid prerelease at semantic version name '%s': %v", name, err)
+		}
+	}
+
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("there are not exactly the items 'major.minor.patch' at semantic version name '%s'", name)
+	}
+	numbers := make([]int, len(parts))
+	for i, part := range parts {
+		if !isNumeric(part) || (len(part) > 1 && part[0] == '0') {
+			return nil, fmt.Errorf("the element '%s' is not a number without leading zeros at semantic version name '%s'", part, name)
+		}
+		number, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("failed to format number '%s' at semantic version name '%s': %v", part, name, err)
+		}
+		numbers[i] = number
+	}
+
+	result := Version{Name: name, Numbers: numbers, Qualifier: prerelease, Build: build, Semantic: true}
+	result.QualifierKind, result.QualifierNumber = classifyQualifier(prerelease)
+	return &result, nil
+}
+
+// returns the major number
+func (v *Version) Major() int {
+	return v.Numbers[0]
+}
+
+// returns the minor number
+func (v *Version) Minor() int {
+	return v.Numbers[1]
+}
+
+// returns the patch number
+func (v *Version) Patch() int {
+	return v.Numbers[2]
+}
+
+// Indicator whether the version is a snapshot. A maven version is a snapshot if its qualifier ends with "SNAPSHOT".
+// A semantic version is a snapshot if it is a prerelease
+func (v *Version) IsSnapshot() bool {
+	if v.Semantic {
+		return v.Qualifier != ""
+	}
+	return strings.HasSuffix(strings.ToLower(v.Qualifier), "snapshot")
+}
+
+// Indicator whether the version is a prerelease: either a snapshot or a qualifier with lower precedence than a release
+func (v *Version) IsPrerelease() bool {
+	return v.IsSnapshot() || v.QualifierKind < RELEASE
+}
+
+// Compares the version with an other one. The result will be 0 if v == other, -1 if v < other, and +1 if v > other.
+// Build metadata are ignored. Semantic versions are compared according to semantic versioning 2.0, maven versions by the
+// precedence of their qualifiers: alpha < beta < milestone < rc < snapshot < release < sp < unknown qualifiers
+func (v *Version) Compare(other *Version) int {
+	if c := compareNumbers(v.Numbers, other.Numbers); c != 0 {
+		return c
+	}
+	if v.Semantic && other.Semantic {
+		return compareSemanticPrerelease(v.Qualifier, other.Qualifier)
+	}
+	return compareMavenQualifier(v, other)
+}
+
+// returns the name of the version
+func (v *Version) String() string {
+	return v.Name
+}
+
+// removes surrounding white spaces and a leading "v" or "V" in front of a number
+func trimVersionPrefix(name string) string {
+	trimmed := strings.TrimSpace(name)
+	if len(trimmed) > 1 && (trimmed[0] == 'v' || trimmed[0] == 'V') && isDigit(trimmed[1]) {
+		return trimmed[1:]
+	}
+	return trimmed
+}
+
+// fills up missing minor and patch numbers with zero
+func fillNumbers(numbers []int) []int {
+	for len(numbers) < 3 {
+		numbers = append(numbers, 0)
+	}
+	return numbers
+}
+
+// classifies a qualifier and determines the number which follows the qualifier kind
+func classifyQualifier(qualifier string) (QualifierKind, int) {
+	lowerQualifier := strings.ToLower(qualifier)
+	if lowerQualifier == "" {
+		return RELEASE, 0
+	}
+
+	letterEnd := strings.IndexFunc(lowerQualifier, func(r rune) bool { return r < 'a' || r > 'z' })
+	if letterEnd < 0 {
+		letterEnd = len(lowerQualifier)
+	}
+	token := lowerQualifier[:letterEnd]
+	number, hasNumber := leadingNumber(strings.TrimLeft(lowerQualifier[letterEnd:], "-."))
+
+	switch {
+	case token == "alpha" || (token == "a" && hasNumber):
+		return ALPHA, number
+	case token == "beta" || (token == "b" && hasNumber):
+		return BETA, number
+	case token == "milestone" || (token == "m" && hasNumber):
+		return MILESTONE, number
+	case token == "rc" || token == "cr":
+		return RELEASE_CANDIDATE, number
+	case token == "snapshot":
+		return SNAPSHOT, number
+	case token == "ga" || token == "final" || token == "release":
+		return RELEASE, number
+	case token == "sp":
+		return SERVICE_PACK, number
+	default:
+		return UNKNOWN, number
+	}
+}
+
+// parses the leading digits of a text
+func leadingNumber(text string) (int, bool) {
+	end := 0
+	for end < len(text) && isDigit(text[end]) {
+		end++
+	}
+	if end == 0 {
+		return 0, false
+	}
+	number, err := strconv.Atoi(text[:end])
+	return number, err == nil
+}
+
+// compares two lists of numbers. Missing numbers are handled as zero
+func compareNumbers(numbers []int, otherNumbers []int) int {
+	for i := 0; i < max(len(numbers), len(otherNumbers)); i++ {
+		if c := cmp.Compare(numberAt(numbers, i), numberAt(otherNumbers, i)); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// returns the number at an index or zero if there is none
+func numberAt(numbers []int, index int) int {
+	if index < len(numbers) {
+		return numbers[index]
+	}
+	return 0
+}
+
+// compares two prereleases according to semantic versioning 2.0. A version without prerelease has a higher precedence
+func compareSemanticPrerelease(prerelease string, otherPrerelease string) int {
+	switch {
+	case prerelease == otherPrerelease:
+		return 0
+	case prerelease == "":
+		return 1
+	case otherPrerelease == "":
+		return -1
+	}
+
+	identifiers := strings.Split(prerelease, ".")
+	otherIdentifiers := strings.Split(otherPrerelease, ".")
+	for i := 0; i < min(len(identifiers), len(otherIdentifiers)); i++ {
+		if c := compareSemanticIdentifier(identifiers[i], otherIdentifiers[i]); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(identifiers), len(otherIdentifiers))
+}
+
+// compares two prerelease identifiers: numeric ones numerically, alphanumeric ones lexically and numeric ones lower than alphanumeric ones
+func compareSemanticIdentifier(identifier string, otherIdentifier string) int {
+	numeric := isNumeric(identifier)
+	otherNumeric := isNumeric(otherIdentifier)
+	switch {
+	case numeric && otherNumeric:
+		number, _ := strconv.Atoi(identifier)
+		otherNumber, _ := strconv.Atoi(otherIdentifier)
+		return cmp.Compare(number, otherNumber)
+	case numeric:
+		return -1
+	case otherNumeric:
+		return 1
+	default:
+		return strings.Compare(identifier, otherIdentifier)
+	}
+}
+
+// compares the qualifiers of two maven versions by their kind, number, snapshot indicator and finally lexically for unknown ones
+func compareMavenQualifier(v *Version, other *Version) int {
+	if c := cmp.Compare(int(v.QualifierKind), int(other.QualifierKind)); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(v.QualifierNumber, other.QualifierNumber); c != 0 {
+		return c
+	}
+	if v.IsSnapshot() != other.IsSnapshot() {
+		if v.IsSnapshot() {
+			return -1
+		}
+		return 1
+	}
+	if v.QualifierKind != UNKNOWN {
+		return 0
+	}
+	return strings.Compare(strings.ToLower(v.Qualifier), strings.ToLower(other.Qualifier))
+}
+
+// checks dot separated identifiers of a prerelease or build metadata. Numeric prerelease identifiers must not have leading zeros
+func checkIdentifiers(identifiers string, isPrerelease bool) error {
+	for _, identifier := range strings.Split(identifiers, ".") {
+		if identifier == "" {
+			return errors.New("empty identifier")
+		}
+		for _, r := range identifier {
+			if !(r == '-' || (r >= '0' && r <= '9') || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')) {
+				return fmt.Errorf("invalid character '%c' at identifier '%s'", r, identifier)
+			}
+		}
+		if isPrerelease && isNumeric(identifier) && len(identifier) > 1 && identifier[0] == '0' {
+			return fmt.Errorf("leading zero at numeric identifier '%s'", identifier)
+		}
+	}
+	return nil
+}
+
+// checks whether a text is not empty and consists of digits only
+func isNumeric(text string) bool {
+	if text == "" {
+		return false
+	}
+	for i := 0; i < len(text); i++ {
+		if !isDigit(text[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// checks whether a text starts with a letter
+func startsWithLetter(text string) bool {
+	if text == "" {
+		return false
+	}
+	first := text[0] | 0x20
+	return first >= 'a' && first <= 'z'
+}
+
+// checks whether a byte is a digit
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
@@ -0,0 +1,149 @@
+package version_model
+
+import (
+	"testing"
+
+	"github.com/ma-vin/testutil-go"
+)
+
+func TestParseMavenThreeNumbers(t *testing.T) {
+	version, err := ParseMaven("1.2.3")
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(version, t, "version")
+	testutil.AssertEquals(1, version.Major(), t, "major")
+	testutil.AssertEquals(2, version.Minor(), t, "minor")
+	testutil.AssertEquals(3, version.Patch(), t, "patch")
+	testutil.AssertEquals("", version.Qualifier, t, "qualifier")
+	testutil.AssertEquals(RELEASE, version.QualifierKind, t, "qualifier kind")
+	testutil.AssertFalse(version.IsSnapshot(), t, "snapshot")
+	testutil.AssertFalse(version.IsPrerelease(), t, "prerelease")
+}
+
+func TestParseMavenMissingNumbers(t *testing.T) {
+	version, err := ParseMaven("2")
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(version, t, "version")
+	testutil.AssertEquals(2, version.Major(), t, "major")
+	testutil.AssertEquals(0, version.Minor(), t, "minor")
+	testutil.AssertEquals(0, version.Patch(), t, "patch")
+}
+
+func TestParseMavenFourNumbers(t *testing.T) {
+	version, err := ParseMaven("1.2.3.4")
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(version, t, "version")
+	testutil.AssertEquals(4, len(version.Numbers), t, "number of numbers")
+	testutil.AssertEquals(4, version.Numbers[3], t, "fourth number")
+}
+
+func TestParseMavenPrefixQualifierAndBuild(t *testing.T) {
+	version, err := ParseMaven("v1.2.3-RC1+build.5")
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(version, t, "version")
+	testutil.AssertEquals("v1.2.3-RC1+build.5", version.Name, t, "name")
+	testutil.AssertEquals(3, version.Patch(), t, "patch")
+	testutil.AssertEquals("RC1", version.Qualifier, t, "qualifier")
+	testutil.AssertEquals(RELEASE_CANDIDATE, version.QualifierKind, t, "qualifier kind")
+	testutil.AssertEquals(1, version.QualifierNumber, t, "qualifier number")
+	testutil.AssertEquals("build.5", version.Build, t, "build")
+	testutil.AssertFalse(version.IsSnapshot(), t, "snapshot")
+	testutil.AssertTrue(version.IsPrerelease(), t, "prerelease")
+}
+
+func TestParseMavenDotQualifier(t *testing.T) {
+	version, err := ParseMaven("5.3.1.RELEASE")
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(version, t, "version")
+	testutil.AssertEquals(1, version.Patch(), t, "patch")
+	testutil.AssertEquals("RELEASE", version.Qualifier, t, "qualifier")
+	testutil.AssertEquals(RELEASE, version.QualifierKind, t, "qualifier kind")
+}
+
+func TestParseMavenSnapshot(t *testing.T) {
+	version, err := ParseMaven("1.2.3-beta-2-SNAPSHOT")
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(version, t, "version")
+	testutil.AssertEquals("beta-2-SNAPSHOT", version.Qualifier, t, "qualifier")
+	testutil.AssertEquals(BETA, version.QualifierKind, t, "qualifier kind")
+	testutil.AssertEquals(2, version.QualifierNumber, t, "qualifier number")
+	testutil.AssertTrue(version.IsSnapshot(), t, "snapshot")
+}
+
+func TestParseMavenInvalid(t *testing.T) {
+	for _, name := range []string{"", "a.b.c", "3a.0.0", "1..2", "1.2.3-", "1.2.3+", "-1.2.3"} {
+		version, err := ParseMaven(name)
+
+		testutil.AssertNotNil(err, t, "err of "+name)
+		testutil.AssertNil(version, t, "version of "+name)
+	}
+}
+
+func TestParseSemantic(t *testing.T) {
+	version, err := ParseSemantic("1.4.0-beta.3+exp.sha.5114f85")
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(version, t, "version")
+	testutil.AssertEquals(1, version.Major(), t, "major")
+	testutil.AssertEquals(4, version.Minor(), t, "minor")
+	testutil.AssertEquals(0, version.Patch(), t, "patch")
+	testutil.AssertEquals("beta.3", version.Qualifier, t, "qualifier")
+	testutil.AssertEquals(BETA, version.QualifierKind, t, "qualifier kind")
+	testutil.AssertEquals(3, version.QualifierNumber, t, "qualifier number")
+	testutil.AssertEquals("exp.sha.5114f85", version.Build, t, "build")
+	testutil.AssertTrue(version.IsSnapshot(), t, "snapshot")
+	testutil.AssertTrue(version.IsPrerelease(), t, "prerelease")
+}
+
+func TestParseSemanticInvalid(t *testing.T) {
+	for _, name := range []string{"", "1.2", "1.2.3.4", "01.2.3", "1.2.3-", "1.2.3-01", "1.2.3-beta..1", "1.2.3+", "1.2.3-beta_1", "a.b.c"} {
+		version, err := ParseSemantic(name)
+
+		testutil.AssertNotNil(err, t, "err of "+name)
+		testutil.AssertNil(version, t, "version of "+name)
+	}
+}
+
+func TestCompareSemanticPrecedence(t *testing.T) {
+	// order of precedence at https://semver.org/#spec-item-11
+	names := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1", "1.1.0", "2.0.0"}
+	checkAscendingOrder(names, ParseSemantic, t)
+}
+
+func TestCompareSemanticIgnoresBuild(t *testing.T) {
+	version, _ := ParseSemantic("1.2.3+build.5")
+	other, _ := ParseSemantic("1.2.3+build.6")
+
+	testutil.AssertEquals(0, version.Compare(other), t, "compare")
+}
+
+func TestCompareMavenPrecedence(t *testing.T) {
+	names := []string{"1.0-alpha1", "1.0-alpha-2", "1.0-beta1", "1.0-M1", "1.0-RC1-SNAPSHOT", "1.0-RC1", "1.0-cr2", "1.0-SNAPSHOT", "1.0", "1.0-sp1", "1.0-jdk8", "1.0.1", "1.0.1.1", "1.1"}
+	checkAscendingOrder(names, ParseMaven, t)
+}
+
+func TestCompareMavenEqualWithMissingNumbers(t *testing.T) {
+	version, _ := ParseMaven("1")
+	other, _ := ParseMaven("1.0.0.0")
+	release, _ := ParseMaven("1.0.0.RELEASE")
+
+	testutil.AssertEquals(0, version.Compare(other), t, "compare missing numbers")
+	testutil.AssertEquals(0, version.Compare(release), t, "compare release qualifier")
+}
+
+func checkAscendingOrder(names []string, parse func(string) (*Version, error), t *testing.T) {
+	for i := 0; i+1 < len(names); i++ {
+		lower, err := parse(names[i])
+		testutil.AssertNil(err, t, "err of "+names[i])
+		greater, err := parse(names[i+1])
+		testutil.AssertNil(err, t, "err of "+names[i+1])
+
+		testutil.AssertEquals(-1, lower.Compare(greater), t, names[i]+" < "+names[i+1])
+		testutil.AssertEquals(1, greater.Compare(lower), t, names[i+1]+" > "+names[i])
+	}
+}
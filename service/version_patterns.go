@@ -0,0 +1,92 @@
+package service
+
+import (
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/ma-vin/packages-action/config"
+)
+
+// A name pattern of versions or packages: either a regular expression or a glob which is matched case insensitive
+type namePattern struct {
+	text  string
+	regex *regexp.Regexp
+	glob  string
+}
+
+// The compiled version name patterns of a configuration
+type versionPatterns struct {
+	toDelete []namePattern
+	toKeep   []namePattern
+}
+
+// Compiles the version patterns to delete and to keep of the configuration
+func compileVersionPatterns(config *config.Config) (*versionPatterns, error) {
+	toDelete, err := compilePatterns(&config.VersionPatternsToDelete)
+	if err != nil {
+		return nil, err
+	}
+	toKeep, err := compilePatterns(&config.VersionPatternsToKeep)
+	if err != nil {
+		return nil, err
+	}
+	return &versionPatterns{toDelete, toKeep}, nil
+}
+
+// Compiles patterns: with prefix "regex:" as regular expression, otherwise as glob
+func compilePatterns(patterns *[]string) ([]namePattern, error) {
+	result := make([]namePattern, 0, len(*patterns))
+	for _, pattern := range *patterns {
+		if regex, isRegex := strings.CutPrefix(pattern, config.REGEX_PATTERN_PREFIX); isRegex {
+			compiled, err := regexp.Compile(regex)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, namePattern{text: pattern, regex: compiled})
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, err
+		}
+		result = append(result, namePattern{text: pattern, glob: strings.ToLower(pattern)})
+	}
+	return result, nil
+}
+
+// Checks whether the pattern matches the name
+func (pattern *namePattern) matches(name string) bool {
+	if pattern.regex != nil {
+		return pattern.regex.MatchString(name)
+	}
+	matched, _ := path.Match(pattern.glob, strings.ToLower(name))
+	return matched
+}
+
+// Checks whether any of the patterns matches any of the names
+func matchesAnyPattern(patterns []namePattern, names ...string) bool {
+	_, _, found := findMatchingPattern(patterns, names...)
+	return found
+}
+
+// Determines the first pattern which matches any of the names. The results are the text of the pattern, the matched name and whether there is any match at all
+func findMatchingPattern(patterns []namePattern, names ...string) (string, string, bool) {
+	for _, pattern := range patterns {
+		for _, name := range names {
+			if pattern.matches(name) {
+				return pattern.text, name, true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// Checks whether any of the names matches a pattern to keep
+func (patterns *versionPatterns) isToKeep(names ...string) bool {
+	return matchesAnyPattern(patterns.toKeep, names...)
+}
+
+// Determines the first pattern to delete which matches any of the names. The results are the text of the pattern, the matched name and whether there is any match at all
+func (patterns *versionPatterns) findToDelete(names ...string) (string, string, bool) {
+	return findMatchingPattern(patterns.toDelete, names...)
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ma-vin/testutil-go"
+)
+
+func TestDetermineCandidatesGlobPatternToDelete(t *testing.T) {
+	initCandidateTest()
+
+	candidatesConf.VersionPatternsToDelete = []string{"1.1.*"}
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(candidates, t, "candidates")
+	testutil.AssertEquals(2, len(*candidates), t, "len candidates")
+	testutil.AssertEquals(3, (*candidates)[0].Id, t, "first id")
+	testutil.AssertEquals(4, (*candidates)[1].Id, t, "second id")
+}
+
+func TestDetermineCandidatesGlobPatternCaseInsensitive(t *testing.T) {
+	initCandidateTest()
+
+	candidatesConf.VersionPatternsToDelete = []string{"*-feature-*"}
+	candidateVersionTwo.Name = "1.1.0-FEATURE-abc"
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(candidates, t, "candidates")
+	testutil.AssertEquals(1, len(*candidates), t, "len candidates")
+	testutil.AssertEquals(3, (*candidates)[0].Id, t, "id")
+}
+
+func TestDetermineCandidatesRegexPatternToDelete(t *testing.T) {
+	initCandidateTest()
+
+	candidatesConf.VersionPatternsToDelete = []string{"regex:^1\\.0\\.\\d+$", "regex:^9"}
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(candidates, t, "candidates")
+	testutil.AssertEquals(1, len(*candidates), t, "len candidates")
+	testutil.AssertEquals(2, (*candidates)[0].Id, t, "id")
+}
+
+func TestDetermineCandidatesUnparseableVersionPattern(t *testing.T) {
+	initCandidateTest()
+
+	candidatesConf.VersionPatternsToDelete = []string{"feature-*"}
+	candidateVersionOne.Name = "feature-abc"
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(candidates, t, "candidates")
+	testutil.AssertEquals(1, len(*candidates), t, "len candidates")
+	testutil.AssertEquals(2, (*candidates)[0].Id, t, "id")
+}
+
+func TestDetermineCandidatesPatternToKeepWins(t *testing.T) {
+	initCandidateTest()
+
+	candidatesConf.NumberOfMinorVersionsToKeep = 1
+	candidatesConf.VersionNameToDelete = "1.1.1-lts"
+	candidatesConf.VersionPatternsToDelete = []string{"*"}
+	candidatesConf.VersionPatternsToKeep = []string{"*-LTS", "regex:^1\\.0"}
+	candidateVersionThreee.Name = "1.1.1-lts"
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(candidates, t, "candidates")
+	testutil.AssertEquals(1, len(*candidates), t, "len candidates")
+	testutil.AssertEquals(3, (*candidates)[0].Id, t, "id")
+}
+
+func TestDetermineCandidatesInvalidPattern(t *testing.T) {
+	initCandidateTest()
+
+	candidatesConf.VersionPatternsToDelete = []string{"regex:1.("}
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNotNil(err, t, "err")
+	testutil.AssertNil(candidates, t, "candidates")
+}
+
+func TestDetermineContainerCandidatesPatterns(t *testing.T) {
+	initContainerCandidateTest([]string{"pr-1"}, []string{"pr-2", "stable"}, []string{"2.0.0"})
+
+	candidatesConf.VersionPatternsToDelete = []string{"pr-*", "sha256:3333"}
+	candidatesConf.VersionPatternsToKeep = []string{"stable"}
+
+	candidates, err := DetermineCandidates(context.Background(), &candidatesConf)
+
+	testutil.AssertNil(err, t, "err")
+	testutil.AssertNotNil(candidates, t, "candidates")
+	testutil.AssertEquals(2, len(*candidates), t, "len candidates")
+	testutil.AssertEquals(2, (*candidates)[0].Id, t, "first id")
+	testutil.AssertEquals(4, (*candidates)[1].Id, t, "second id")
+}
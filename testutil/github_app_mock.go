@@ -0,0 +1,105 @@
+package testutil
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ma-vin/typewriter/logger"
+)
+
+var CreateInstallationTokenCounter int
+
+// authorization headers of all requests except those to create installation tokens
+var requestAuthorizations []string
+var authorizationMutex sync.Mutex
+
+// adds an installation of a GitHub App to the mock. Installation tokens are only created for a valid JWT of the app which is signed
+// by the private key of the public key. Each created token is valid for the given lifetime
+func AddAppInstallationToMock(appId string, installationId int, publicKey *rsa.PublicKey, tokenLifetime time.Duration) {
+	createTokenUrl := fmt.Sprintf("/app/installations/%d/access_tokens", installationId)
+	mux.HandleFunc(createTokenUrl, createInstallationTokenHandler(appId, publicKey, tokenLifetime))
+}
+
+// returns the authorization headers of the requests against the mock which were not requests to create installation tokens
+func GetRequestAuthorizations() []string {
+	authorizationMutex.Lock()
+	defer authorizationMutex.Unlock()
+	return append([]string{}, requestAuthorizations...)
+}
+
+func recordAuthorization(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/app/") {
+			authorizationMutex.Lock()
+			requestAuthorizations = append(requestAuthorizations, r.Header.Get("Authorization"))
+			authorizationMutex.Unlock()
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+func createInstallationTokenHandler(appId string, publicKey *rsa.PublicKey, tokenLifetime time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger.Informationf("Mock - createInstallationTokenHandler %s '%s'", r.Method, r.URL)
+		if r.Method != http.MethodPost {
+			w.WriteHeader(500)
+			return
+		}
+		if err := verifyAppJwt(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), appId, publicKey); err != nil {
+			logger.Errorf("Mock - invalid JWT: %v", err)
+			w.WriteHeader(401)
+			return
+		}
+		CreateInstallationTokenCounter++
+		w.Header().Set("Content-Type", gitHubModelJsonType)
+		w.WriteHeader(201)
+		json.NewEncoder(w).Encode(map[string]string{
+			"token":      fmt.Sprintf("installation-token-%d", CreateInstallationTokenCounter),
+			"expires_at": time.Now().Add(tokenLifetime).UTC().Format(time.RFC3339),
+		})
+	}
+}
+
+// verifies the RS256 signature, the issuer and the expiry of the JWT of a GitHub App
+func verifyAppJwt(jwt string, appId string, publicKey *rsa.PublicKey) error {
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		return fmt.Errorf("JWT with %d parts", len(parts))
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return err
+	}
+	hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err = rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, hash[:], signature); err != nil {
+		return err
+	}
+
+	content, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return err
+	}
+	var claims struct {
+		IssuedAt  int64  `json:"iat"`
+		ExpiresAt int64  `json:"exp"`
+		Issuer    string `json:"iss"`
+	}
+	if err = json.Unmarshal(content, &claims); err != nil {
+		return err
+	}
+	if claims.Issuer != appId {
+		return fmt.Errorf("issuer %s instead of %s", claims.Issuer, appId)
+	}
+	if claims.ExpiresAt <= time.Now().Unix() || claims.ExpiresAt-claims.IssuedAt > int64((10*time.Minute).Seconds()) {
+		return fmt.Errorf("expired or too long valid JWT: iat %d exp %d", claims.IssuedAt, claims.ExpiresAt)
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 
 	"github.com/ma-vin/packages-action/service/github_model"
 	"github.com/ma-vin/typewriter/logger"
@@ -13,57 +14,138 @@ import (
 const gitHubModelJsonType string = "application/vnd.github+json"
 
 var server *httptest.Server
-var versionsData *[]github_model.Version
-var packageData *github_model.UserPackage
+var mux *http.ServeMux
+var mockOwnerUrlPart string
+var mockOwnerName string
+var mockPackageType string
+var packagesData []github_model.UserPackage
+var deletedVersionsData map[string]*[]github_model.Version
+var mockTokenScopes *string
 
 var GetUserPackageVersionsCounter int
 var DeleteUserPackageVersionCounter int
 var GetUserPackageCounter int
 var DeleteUserPackageCounter int
 var GetAllUserPackagesCounter int
+var GetDeletedUserPackageVersionsCounter int
+var RestoreUserPackageVersionCounter int
+var RestoreUserPackageCounter int
 
+// creates and starts a mock server which provides the packages of a user
 func CreateAndStartMock(userName string, packageType string, packageName string, versions *[]github_model.Version, userPackage *github_model.UserPackage) string {
-	versionsData = versions
-	packageData = userPackage
+	return createAndStartMock("users", userName, packageType, packageName, versions, userPackage)
+}
 
-	mux := http.NewServeMux()
+// creates and starts a mock server which provides the packages of an organization
+func CreateAndStartOrganizationMock(organizationName string, packageType string, packageName string, versions *[]github_model.Version, userPackage *github_model.UserPackage) string {
+	return createAndStartMock("orgs", organizationName, packageType, packageName, versions, userPackage)
+}
 
-	getVersionsUrl := fmt.Sprintf("/users/%s/packages/%s/%s/versions", userName, packageType, packageName)
-	mux.HandleFunc(getVersionsUrl, getUserPackageVersionsHandler)
-	GetUserPackageVersionsCounter = 0
+func createAndStartMock(ownerUrlPart string, ownerName string, packageType string, packageName string, versions *[]github_model.Version, userPackage *github_model.UserPackage) string {
+	mockOwnerUrlPart = ownerUrlPart
+	mockOwnerName = ownerName
+	mockPackageType = packageType
+	packagesData = []github_model.UserPackage{}
+	deletedVersionsData = map[string]*[]github_model.Version{}
+	mockTokenScopes = nil
 
-	deleteVersionUrl := fmt.Sprintf("/users/%s/packages/%s/%s/versions/{id}", userName, packageType, packageName)
-	mux.HandleFunc(deleteVersionUrl, deleteUserPackageVersionHandler)
-	DeleteUserPackageVersionCounter = 0
+	mux = http.NewServeMux()
 
-	getPackageUrl := fmt.Sprintf("/users/%s/packages/%s/%s", userName, packageType, packageName)
-	mux.HandleFunc(getPackageUrl, getOrDeleteUserPackageHandler)
+	GetUserPackageVersionsCounter = 0
+	DeleteUserPackageVersionCounter = 0
 	GetUserPackageCounter = 0
 	DeleteUserPackageCounter = 0
+	GetAllUserPackagesCounter = 0
+	GetDeletedUserPackageVersionsCounter = 0
+	RestoreUserPackageVersionCounter = 0
+	RestoreUserPackageCounter = 0
 
-	getAllPackagesUrl := fmt.Sprintf("/users/%s/packages", userName)
+	AddPackageToMock(packageName, versions, userPackage)
+
+	getAllPackagesUrl := fmt.Sprintf("/%s/%s/packages", ownerUrlPart, ownerName)
 	mux.HandleFunc(getAllPackagesUrl, getAllUserPackagesHandler)
-	GetAllUserPackagesCounter = 0
 
-	server = httptest.NewServer(mux)
+	requestAuthorizations = []string{}
+	CreateInstallationTokenCounter = 0
+
+	server = httptest.NewServer(recordAuthorization(addTokenScopes(mux)))
 	logger.Information("Mock - server started")
 
 	return server.URL
 }
 
+// adds a further package with its versions to the mock. If the user package is nil, only the versions are provided
+func AddPackageToMock(packageName string, versions *[]github_model.Version, userPackage *github_model.UserPackage) {
+	if userPackage != nil {
+		packagesData = append(packagesData, *userPackage)
+	}
+
+	// like GitHub, the state of the versions is only filtered at organizations and at the packages of the authenticated user
+	isOrganization := mockOwnerUrlPart == "orgs"
+	getVersionsUrl := fmt.Sprintf("/%s/%s/packages/%s/%s/versions", mockOwnerUrlPart, mockOwnerName, mockPackageType, packageName)
+	mux.HandleFunc(getVersionsUrl, createGetUserPackageVersionsHandler(packageName, versions, isOrganization))
+	if !isOrganization {
+		getAuthenticatedUserVersionsUrl := fmt.Sprintf("/user/packages/%s/%s/versions", mockPackageType, packageName)
+		mux.HandleFunc(getAuthenticatedUserVersionsUrl, createGetUserPackageVersionsHandler(packageName, versions, true))
+	}
+
+	deleteVersionUrl := fmt.Sprintf("/%s/%s/packages/%s/%s/versions/{id}", mockOwnerUrlPart, mockOwnerName, mockPackageType, packageName)
+	mux.HandleFunc(deleteVersionUrl, deleteUserPackageVersionHandler)
+
+	getPackageUrl := fmt.Sprintf("/%s/%s/packages/%s/%s", mockOwnerUrlPart, mockOwnerName, mockPackageType, packageName)
+	mux.HandleFunc(getPackageUrl, createGetOrDeleteUserPackageHandler(userPackage))
+
+	restoreVersionUrl := fmt.Sprintf("/%s/%s/packages/%s/%s/versions/{id}/restore", mockOwnerUrlPart, mockOwnerName, mockPackageType, packageName)
+	mux.HandleFunc(restoreVersionUrl, restoreUserPackageVersionHandler)
+
+	restorePackageUrl := fmt.Sprintf("/%s/%s/packages/%s/%s/restore", mockOwnerUrlPart, mockOwnerName, mockPackageType, packageName)
+	mux.HandleFunc(restorePackageUrl, restoreUserPackageHandler)
+}
+
+// adds deleted versions of a package to the mock. They are provided by the versions of the package with state "deleted"
+func AddDeletedVersionsToMock(packageName string, versions *[]github_model.Version) {
+	deletedVersionsData[packageName] = versions
+}
+
+// sets the scopes of the token which are returned by the X-OAuth-Scopes header of each response, like GitHub does for classic tokens
+func SetTokenScopesOfMock(scopes string) {
+	mockTokenScopes = &scopes
+}
+
 func StopMock() {
 	server.Close()
 	logger.Information("Mock - server stopped")
 }
 
-func getUserPackageVersionsHandler(w http.ResponseWriter, r *http.Request) {
-	logger.Informationf("Mock - getUserPackageVersionsHandler %s '%s'", r.Method, r.URL)
-	if r.Method == http.MethodGet {
-		GetUserPackageVersionsCounter++
+func addTokenScopes(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if mockTokenScopes != nil {
+			w.Header().Set("X-OAuth-Scopes", *mockTokenScopes)
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+func createGetUserPackageVersionsHandler(packageName string, versionsData *[]github_model.Version, filtersState bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger.Informationf("Mock - getUserPackageVersionsHandler %s '%s'", r.Method, r.URL)
+		if r.Method != http.MethodGet {
+			w.WriteHeader(500)
+			return
+		}
+		data := versionsData
+		if filtersState && r.URL.Query().Get("state") == "deleted" {
+			GetDeletedUserPackageVersionsCounter++
+			data = deletedVersionsData[packageName]
+		} else {
+			GetUserPackageVersionsCounter++
+		}
 		w.Header().Set("Content-Type", gitHubModelJsonType)
-		json.NewEncoder(w).Encode(versionsData)
-	} else {
-		w.WriteHeader(500)
+		if data == nil {
+			json.NewEncoder(w).Encode(data)
+			return
+		}
+		writePage(w, r, *data)
 	}
 }
 
@@ -78,18 +160,40 @@ func deleteUserPackageVersionHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func getOrDeleteUserPackageHandler(w http.ResponseWriter, r *http.Request) {
-	logger.Informationf("Mock - getOrDeleteUserPackageHandler %s '%s'", r.Method, r.URL)
-	switch r.Method {
-	case http.MethodGet:
-		GetUserPackageCounter++
-		w.Header().Set("Content-Type", gitHubModelJsonType)
-		json.NewEncoder(w).Encode(packageData)
-	case http.MethodDelete:
-		DeleteUserPackageCounter++
-		w.Header().Set("Content-Type", gitHubModelJsonType)
+func createGetOrDeleteUserPackageHandler(packageData *github_model.UserPackage) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger.Informationf("Mock - getOrDeleteUserPackageHandler %s '%s'", r.Method, r.URL)
+		switch r.Method {
+		case http.MethodGet:
+			GetUserPackageCounter++
+			w.Header().Set("Content-Type", gitHubModelJsonType)
+			json.NewEncoder(w).Encode(packageData)
+		case http.MethodDelete:
+			DeleteUserPackageCounter++
+			w.Header().Set("Content-Type", gitHubModelJsonType)
+			w.WriteHeader(204)
+		default:
+			w.WriteHeader(500)
+		}
+	}
+}
+
+func restoreUserPackageVersionHandler(w http.ResponseWriter, r *http.Request) {
+	logger.Informationf("Mock - restoreUserPackageVersionHandler %s '%s'", r.Method, r.URL)
+	if r.Method == http.MethodPost {
+		RestoreUserPackageVersionCounter++
+		w.WriteHeader(204)
+	} else {
+		w.WriteHeader(500)
+	}
+}
+
+func restoreUserPackageHandler(w http.ResponseWriter, r *http.Request) {
+	logger.Informationf("Mock - restoreUserPackageHandler %s '%s'", r.Method, r.URL)
+	if r.Method == http.MethodPost {
+		RestoreUserPackageCounter++
 		w.WriteHeader(204)
-	default:
+	} else {
 		w.WriteHeader(500)
 	}
 }
@@ -97,17 +201,34 @@ func getOrDeleteUserPackageHandler(w http.ResponseWriter, r *http.Request) {
 func getAllUserPackagesHandler(w http.ResponseWriter, r *http.Request) {
 	logger.Informationf("Mock - getAllUserPackagesHandler %s '%s'", r.Method, r.URL)
 	if r.Method == http.MethodGet {
-		var data *[]github_model.UserPackage
-		if packageData != nil {
-			data = &[]github_model.UserPackage{*packageData}
-		} else {
-			data = &[]github_model.UserPackage{}
-		}
-
 		GetAllUserPackagesCounter++
 		w.Header().Set("Content-Type", gitHubModelJsonType)
-		json.NewEncoder(w).Encode(data)
+		writePage(w, r, packagesData)
 	} else {
 		w.WriteHeader(500)
 	}
 }
+
+// writes the page of the data which is requested by the query parameters "per_page" and "page".
+// If there is a following page, a link header with relation "next" is added. Without "per_page" all data are written
+func writePage[T any](w http.ResponseWriter, r *http.Request, data []T) {
+	perPage, err := strconv.Atoi(r.URL.Query().Get("per_page"))
+	if err != nil || perPage <= 0 {
+		json.NewEncoder(w).Encode(data)
+		return
+	}
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page <= 0 {
+		page = 1
+	}
+
+	start := min((page-1)*perPage, len(data))
+	end := min(start+perPage, len(data))
+
+	if end < len(data) {
+		query := r.URL.Query()
+		query.Set("page", strconv.Itoa(page+1))
+		w.Header().Set("Link", fmt.Sprintf(`<http://%s%s?%s>; rel="next"`, r.Host, r.URL.Path, query.Encode()))
+	}
+	json.NewEncoder(w).Encode(data[start:end])
+}